@@ -0,0 +1,196 @@
+package fakedb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Columns of the booking tables, in the order sqlc selects them. Intervals are
+// held as seconds.
+var (
+	bookableColumns = []string{"id", "location_id", "capacity", "description", "default_booking_length", "custom_booking_length", "clear_time", "created_at"}
+	bookingColumns  = []string{"id", "bookable_id", "booked_by", "start_time", "end_time", "capacity", "created_at"}
+)
+
+// SELECT of booking WHERE bookable_id = $1 AND start_time < $2 AND end_time > $3
+// ORDER BY start_time, id
+func listBookableBookings(db *database, args []driver.Value) (*result, error) {
+	start, end, err := timeRange(args[2], args[1])
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.table("booking").filter(func(r row) (bool, error) {
+		return args[0] != nil && r["bookable_id"] == args[0] &&
+			r["start_time"].(time.Time).Before(end) && r["end_time"].(time.Time).After(start), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i]["start_time"].(time.Time).Before(rows[j]["start_time"].(time.Time))
+	})
+	return project(rows, bookingColumns), nil
+}
+
+// SELECT the free capacity and whether the length is allowed for bookable $3
+// between $1 and $2
+func getBookableAvailability(db *database, args []driver.Value) (*result, error) {
+	columns := []string{"id", "free_capacity", "length_allowed"}
+	b := db.table("bookable").get(args[2])
+	if b == nil {
+		return project(nil, columns), nil
+	}
+	start, end, err := timeRange(args[0], args[1])
+	if err != nil {
+		return nil, err
+	}
+	free, err := freeCapacity(db, b, start, end)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := lengthAllowed(db, b, end.Sub(start))
+	if err != nil {
+		return nil, err
+	}
+	return project([]row{b.with("free_capacity", free).with("length_allowed", allowed)}, columns), nil
+}
+
+// SELECT bookables within $4 metres of $1 with at least $5 capacity free and
+// the length allowed between $2 and $3, ORDER BY distance, id LIMIT $6
+func listAvailableBookablesNear(db *database, args []driver.Value) (*result, error) {
+	query, err := decodeShape(args[0])
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := timeRange(args[1], args[2])
+	if err != nil {
+		return nil, err
+	}
+	limit, ok := toFloat(args[3])
+	if !ok {
+		return nil, fmt.Errorf("fakedb: distance of type %T", args[3])
+	}
+	capacity, _ := args[4].(int64)
+
+	bookables, err := db.table("bookable").filter(nil)
+	if err != nil {
+		return nil, err
+	}
+	var matched []row
+	for _, b := range bookables {
+		l := db.table("location").get(b["location_id"])
+		if l == nil {
+			continue
+		}
+		s, err := decodeShape(l["geo"])
+		if err != nil {
+			return nil, err
+		}
+		if s == nil || query == nil {
+			continue
+		}
+		d, err := metres(s, query)
+		if err != nil {
+			return nil, err
+		}
+		if d > limit {
+			continue
+		}
+		allowed, err := lengthAllowed(db, b, end.Sub(start))
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			continue
+		}
+		free, err := freeCapacity(db, b, start, end)
+		if err != nil {
+			return nil, err
+		}
+		if free >= capacity {
+			matched = append(matched, b.with("distance", d).with("free_capacity", free))
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		di, dj := matched[i]["distance"].(float64), matched[j]["distance"].(float64)
+		return di < dj || (di == dj && matched[i]["id"].(int64) < matched[j]["id"].(int64))
+	})
+	return project(page(matched, args[5], int64(0)), []string{"id", "location_id", "capacity", "description", "distance", "free_capacity"}), nil
+}
+
+// Get the capacity of the bookable free throughout start to end, less the peak
+// capacity of the bookings held there at once. Each booking, and the new one,
+// holds the bookable until the clear time after it ends.
+func freeCapacity(db *database, b row, start, end time.Time) (int64, error) {
+	clear := seconds(b["clear_time"])
+	bookings, err := db.table("booking").filter(equals("bookable_id", b["id"]))
+	if err != nil {
+		return 0, err
+	}
+
+	// Peak usage is reached at the start or at a booking start within the window
+	instants := []time.Time{start}
+	for _, k := range bookings {
+		s := k["start_time"].(time.Time)
+		if !s.Before(start) && s.Before(end.Add(clear)) {
+			instants = append(instants, s)
+		}
+	}
+	peak := int64(0)
+	for _, t := range instants {
+		used := int64(0)
+		for _, k := range bookings {
+			if s, e := k["start_time"].(time.Time), k["end_time"].(time.Time).Add(clear); !t.Before(s) && t.Before(e) {
+				used += k["capacity"].(int64)
+			}
+		}
+		peak = max(peak, used)
+	}
+	return b["capacity"].(int64) - peak, nil
+}
+
+// Report whether bookings of the length are allowed for the bookable: any length
+// if it allows custom lengths or has no lengths set, or else its default length
+// or one of its booking lengths
+func lengthAllowed(db *database, b row, length time.Duration) (bool, error) {
+	if b["custom_booking_length"] == true {
+		return true, nil
+	}
+	lengths, err := db.table("booking_lengths").filter(equals("bookable_id", b["id"]))
+	if err != nil {
+		return false, err
+	}
+	if b["default_booking_length"] == nil && len(lengths) == 0 {
+		return true, nil
+	}
+	if b["default_booking_length"] != nil && seconds(b["default_booking_length"]) == length {
+		return true, nil
+	}
+	for _, l := range lengths {
+		if l["default_booking_length"] != nil && seconds(l["default_booking_length"]) == length {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Get an interval held as seconds as a duration, with NULL as zero
+func seconds(value driver.Value) time.Duration {
+	s, _ := toFloat(value)
+	return time.Duration(s * float64(time.Second))
+}
+
+// Get the start and end time arguments of a query
+func timeRange(startValue, endValue driver.Value) (start time.Time, end time.Time, err error) {
+	start, ok := startValue.(time.Time)
+	if !ok {
+		return start, end, fmt.Errorf("fakedb: start time of type %T", startValue)
+	}
+	end, ok = endValue.(time.Time)
+	if !ok {
+		return start, end, fmt.Errorf("fakedb: end time of type %T", endValue)
+	}
+	return start, end, nil
+}
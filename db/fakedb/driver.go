@@ -0,0 +1,219 @@
+/*
+Package fakedb provides an in-memory database/sql driver standing in for a
+PostGIS database, so sqlc queries can be tested without a database server.
+
+Queries are recognised by the name sqlc gives them in the leading
+"-- name: QueryName :kind" comment, rather than by parsing SQL. Each supported
+query is implemented in Go against in-memory tables, with the spatial
+predicates it uses evaluated by a small planar geometry engine. Queries that
+are not supported, including COPY and raw SQL, return an error wrapping
+ErrUnsupported.
+
+The fake is intended for tests only: constraints and foreign keys are not
+enforced, transactions are not isolated from other connections, and spatial
+predicates support points, linestrings and polygons (and collections of
+them) but not curves.
+*/
+package fakedb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+)
+
+// Name the fake driver is registered with in database/sql
+const DriverName = "fakepostgis"
+
+// ErrUnsupported is wrapped by errors for queries the fake does not implement
+var ErrUnsupported = errors.New("fakedb: unsupported")
+
+func init() {
+	sql.Register(DriverName, &Driver{})
+}
+
+// In-memory databases, by data source name
+var (
+	databasesMu sync.Mutex
+	databases   = map[string]*database{}
+)
+
+// Open a *sql.DB backed by the in-memory database with the given name.
+// Connections to the same name share their data.
+func Open(name string) (*sql.DB, error) {
+	return sql.Open(DriverName, name)
+}
+
+// Remove all data from the in-memory database with the given name
+func Reset(name string) {
+	databasesMu.Lock()
+	defer databasesMu.Unlock()
+	delete(databases, name)
+}
+
+// Get the in-memory database with the given name, creating it if necessary
+func getDatabase(name string) *database {
+	databasesMu.Lock()
+	defer databasesMu.Unlock()
+	db, ok := databases[name]
+	if !ok {
+		db = newDatabase()
+		databases[name] = db
+	}
+	return db
+}
+
+// Driver implements database/sql/driver.Driver for in-memory databases
+type Driver struct{}
+
+// Open a connection to the in-memory database with the given name
+func (d *Driver) Open(name string) (driver.Conn, error) {
+	return &conn{name: name}, nil
+}
+
+// A connection to an in-memory database
+type conn struct {
+	name     string
+	snapshot map[string]*table // tables at the start of the open transaction
+}
+
+func (c *conn) db() *database {
+	return getDatabase(c.name)
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if c.snapshot != nil {
+		return nil, fmt.Errorf("fakedb: transaction already open")
+	}
+	c.snapshot = c.db().copyTables()
+	return c, nil
+}
+
+// Commit the open transaction, implementing driver.Tx
+func (c *conn) Commit() error {
+	c.snapshot = nil
+	return nil
+}
+
+// Roll back the open transaction, restoring the tables at its start, implementing driver.Tx
+func (c *conn) Rollback() error {
+	if c.snapshot == nil {
+		return fmt.Errorf("fakedb: no transaction open")
+	}
+	c.db().restoreTables(c.snapshot)
+	c.snapshot = nil
+	return nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	res, err := c.db().run(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &rows{result: res}, nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	res, err := c.db().run(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(res.affected), nil
+}
+
+// A prepared statement, which runs its query when executed
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.ExecContext(context.Background(), s.query, namedValues(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.QueryContext(context.Background(), s.query, namedValues(args))
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
+
+// The rows returned by a query
+type rows struct {
+	result *result
+	next   int
+}
+
+func (r *rows) Columns() []string {
+	return r.result.columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.next >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.next])
+	r.next++
+	return nil
+}
+
+// Matches the name sqlc gives a query
+var queryNamePattern = regexp.MustCompile(`^\s*-- name: (\w+) :\w+`)
+
+// Run a query by its sqlc name
+func (db *database) run(query string, args []driver.NamedValue) (*result, error) {
+	m := queryNamePattern.FindStringSubmatch(query)
+	if m == nil {
+		return nil, fmt.Errorf("%w query without sqlc name: %.40q", ErrUnsupported, query)
+	}
+	handler, ok := queries[m[1]]
+	if !ok {
+		return nil, fmt.Errorf("%w query %v", ErrUnsupported, m[1])
+	}
+
+	values := make([]driver.Value, len(args))
+	for _, arg := range args {
+		if arg.Ordinal < 1 || arg.Ordinal > len(args) {
+			return nil, fmt.Errorf("fakedb: query %v has argument ordinal %v out of range", m[1], arg.Ordinal)
+		}
+		values[arg.Ordinal-1] = arg.Value
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return handler(db, values)
+}
@@ -0,0 +1,90 @@
+package fakedb_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenirven/go-postgis/db/fakedb"
+	db "github.com/stephenirven/go-postgis/db/sqlc"
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestRoundTrip(t *testing.T) {
+	queries := db.New(makeTestDB(t))
+
+	point, err := geo.NewPoint([]float64{1, 2}, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := geo.NewGISGeometry(point)
+	g.SetSRID(4326)
+
+	created, err := queries.CreateGISData(context.Background(), g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := queries.GetGISData(context.Background(), created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(g, got) {
+		t.Errorf("gisdata %v was not equal to %v", got, g)
+	}
+
+	extent, err := queries.GetGISDataExtent(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !extent.Valid || extent.Box2D != (geo.Box2D{MinX: 1, MinY: 2, MaxX: 1, MaxY: 2}) {
+		t.Errorf("extent %v, expected BOX(1 2,1 2)", extent)
+	}
+
+	if err := queries.DeleteGISData(context.Background(), created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.GetGISData(context.Background(), created.ID); err == nil {
+		t.Error("deleted gisdata was returned")
+	}
+}
+
+func TestRollback(t *testing.T) {
+	testDB := makeTestDB(t)
+
+	tx, err := testDB.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	organisation, err := db.New(tx).CreateOrganisation(context.Background(), db.CreateOrganisationParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.New(testDB).GetOrganisation(context.Background(), organisation.ID); err == nil {
+		t.Error("organisation created in a rolled back transaction was returned")
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	queries := db.New(makeTestDB(t))
+
+	_, err := queries.GetOrganisationCoverage(context.Background(), sql.NullInt64{Int64: 1, Valid: true})
+	if !errors.Is(err, fakedb.ErrUnsupported) {
+		t.Errorf("expected %v, got %v", fakedb.ErrUnsupported, err)
+	}
+}
+
+// Open a new in-memory database, reset when the test ends
+func makeTestDB(t *testing.T) *sql.DB {
+	testDB, err := fakedb.Open(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { fakedb.Reset(t.Name()) })
+	return testDB
+}
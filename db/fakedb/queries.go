@@ -0,0 +1,468 @@
+package fakedb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// A query implemented against the in-memory tables, given its arguments in order
+type handler func(db *database, args []driver.Value) (*result, error)
+
+// Columns of each table, in the order sqlc selects them
+var (
+	organisationColumns = []string{"id", "country_code", "merchant_name", "created_at"}
+	userColumns         = []string{"id", "full_name", "email", "mobile", "organisation_id", "created_at"}
+	locationColumns     = []string{"id", "organisation_id", "user_id", "full_name", "line1", "line2", "city", "county", "country_code", "geo", "created_at"}
+)
+
+// Supported queries, by sqlc name
+var queries = map[string]handler{
+	// organisation.sql
+	"CreateOrganisation":       create("organisation", organisationColumns[1:3], organisationColumns, true),
+	"GetOrganisation":          get("organisation", organisationColumns),
+	"GetOrganisationForUpdate": get("organisation", organisationColumns),
+	"ListOrganisations":        list("organisation", organisationColumns, nil),
+	"UpdateOrganisation":       update("organisation", organisationColumns[1:3], organisationColumns),
+	"DeleteOrganisation":       remove("organisation"),
+
+	// user.sql
+	"CreateUser":            create("users", []string{"full_name", "email", "encrypted_password", "mobile", "organisation_id"}, userColumns, true),
+	"GetUser":               get("users", userColumns),
+	"GetUserByEmail":        getUserByEmail(userColumns),
+	"GetUserCredentials":    getUserByEmail([]string{"id", "encrypted_password"}),
+	"ListUsers":             list("users", userColumns, nil),
+	"ListOrganisationUsers": listOrganisationUsers,
+	"UpdateUser":            update("users", []string{"full_name", "email", "mobile", "organisation_id"}, userColumns),
+	"UpdateUserPassword":    update("users", []string{"encrypted_password"}, nil),
+	"DeleteUser":            remove("users"),
+
+	// location.sql
+	"CreateLocation":                  create("location", locationColumns[1:10], locationColumns, true),
+	"GetLocation":                     get("location", locationColumns),
+	"GetLocationForUpdate":            get("location", locationColumns),
+	"GetLocations":                    all("location", locationColumns),
+	"UpdateLocation":                  update("location", locationColumns[1:10], locationColumns),
+	"PatchLocation":                   patchLocation,
+	"UpdateLocationGeo":               updateLocationGeo,
+	"DeleteLocation":                  remove("location"),
+	"GetLocationBox":                  getLocationBox,
+	"GetOrganisationLocationsExtent":  getOrganisationLocationsExtent,
+	"ListLocationsWithinDistance":     withinDistance("location", "geo", locationColumns),
+	"ListLocationsIntersecting":       spatial("location", "geo", locationColumns, intersectsPredicate),
+	"ListLocationsContaining":         spatial("location", "geo", locationColumns, containsPredicate),
+	"ListLocationsWithin":             spatial("location", "geo", locationColumns, withinPredicate),
+	"ListLocationsInBox":              spatial("location", "geo", locationColumns, boxPredicate),
+	"ListNearestLocations":            listNearestLocations,
+	"ListOrganisationLocationsWithin": listOrganisationLocationsWithin,
+
+	// gis.sql
+	"CreateGISData":             create("gisdata", []string{"geo"}, []string{"id", "geo"}, false),
+	"GetGISData":                get("gisdata", []string{"geo"}),
+	"GetAllGISData":             all("gisdata", []string{"geo"}),
+	"DeleteGISData":             remove("gisdata"),
+	"GetGISDataExtent":          getGISDataExtent,
+	"ListGISDataWithinDistance": withinDistance("gisdata", "geo", []string{"geo"}),
+	"ListGISDataIntersecting":   spatial("gisdata", "geo", []string{"id", "geo"}, intersectsPredicate),
+	"ListGISDataContaining":     spatial("gisdata", "geo", []string{"id", "geo"}, containsPredicate),
+	"ListGISDataWithin":         spatial("gisdata", "geo", []string{"id", "geo"}, withinPredicate),
+	"ListGISDataInBox":          spatial("gisdata", "geo", []string{"id", "geo"}, boxPredicate),
+	"ListNearestGISData":        listNearestGISData,
+
+	// geography.sql
+	"CreateGeogData":             create("geogdata", []string{"geog"}, []string{"id", "geog"}, false),
+	"GetGeogData":                get("geogdata", []string{"geog"}),
+	"GetAllGeogData":             all("geogdata", []string{"geog"}),
+	"DeleteGeogData":             remove("geogdata"),
+	"ListGeogDataWithinDistance": withinMetres("geogdata", "geog", []string{"geog"}, false),
+	"ListGISDataWithinMetres":    withinMetres("gisdata", "geo", []string{"geo"}, false),
+	"ListLocationsWithinMetres":  withinMetres("location", "geo", locationColumns, true),
+
+	// booking.sql
+	"CreateBookable":             create("bookable", bookableColumns[1:7], []string{"id"}, true),
+	"CreateBookingLength":        create("booking_lengths", []string{"bookable_id", "default_booking_length"}, []string{"id"}, true),
+	"GetBookableForUpdate":       get("bookable", []string{"id", "location_id", "capacity", "custom_booking_length"}),
+	"GetBookableAvailability":    getBookableAvailability,
+	"ListAvailableBookablesNear": listAvailableBookablesNear,
+	"CreateBooking":              create("booking", bookingColumns[1:6], bookingColumns, true),
+	"ListBookableBookings":       listBookableBookings,
+	"DeleteBooking":              remove("booking"),
+}
+
+// INSERT of the columns from the arguments, RETURNING the returned columns
+func create(tableName string, columns []string, returning []string, createdAt bool) handler {
+	return func(db *database, args []driver.Value) (*result, error) {
+		r, err := db.table(tableName).insert(columns, args, createdAt)
+		if err != nil {
+			return nil, err
+		}
+		return project([]row{r}, returning), nil
+	}
+}
+
+// SELECT of the returned columns WHERE id = $1
+func get(tableName string, returning []string) handler {
+	return func(db *database, args []driver.Value) (*result, error) {
+		r := db.table(tableName).get(args[0])
+		if r == nil {
+			return project(nil, returning), nil
+		}
+		return project([]row{r}, returning), nil
+	}
+}
+
+// SELECT of the returned columns of all rows
+func all(tableName string, returning []string) handler {
+	return func(db *database, args []driver.Value) (*result, error) {
+		rows, err := db.table(tableName).filter(nil)
+		if err != nil {
+			return nil, err
+		}
+		return project(rows, returning), nil
+	}
+}
+
+// SELECT of the returned columns of rows matching the filter ORDER BY id LIMIT $1 OFFSET $2
+func list(tableName string, returning []string, match func(row) (bool, error)) handler {
+	return func(db *database, args []driver.Value) (*result, error) {
+		rows, err := db.table(tableName).filter(match)
+		if err != nil {
+			return nil, err
+		}
+		return project(page(rows, args[0], args[1]), returning), nil
+	}
+}
+
+// UPDATE of the columns WHERE id = $1 from the following arguments, RETURNING the returned columns
+func update(tableName string, columns []string, returning []string) handler {
+	return func(db *database, args []driver.Value) (*result, error) {
+		r := db.table(tableName).get(args[0])
+		if r == nil {
+			return project(nil, returning), nil
+		}
+		if err := r.set(columns, args[1:]); err != nil {
+			return nil, err
+		}
+		return project([]row{r}, returning), nil
+	}
+}
+
+// DELETE WHERE id = $1
+func remove(tableName string) handler {
+	return func(db *database, args []driver.Value) (*result, error) {
+		t := db.table(tableName)
+		if t.get(args[0]) == nil {
+			return &result{}, nil
+		}
+		delete(t.rows, args[0].(int64))
+		return &result{affected: 1}, nil
+	}
+}
+
+// Match rows where the column equals the value, with SQL NULL semantics
+func equals(column string, value driver.Value) func(row) (bool, error) {
+	return func(r row) (bool, error) {
+		return value != nil && r[column] == value, nil
+	}
+}
+
+// SELECT the returned columns WHERE email = $1 LIMIT 1
+func getUserByEmail(returning []string) handler {
+	return func(db *database, args []driver.Value) (*result, error) {
+		rows, err := db.table("users").filter(equals("email", args[0]))
+		if err != nil {
+			return nil, err
+		}
+		return project(page(rows, int64(1), int64(0)), returning), nil
+	}
+}
+
+func listOrganisationUsers(db *database, args []driver.Value) (*result, error) {
+	return list("users", userColumns, equals("organisation_id", args[0]))(db, args[1:])
+}
+
+// UPDATE location set column = COALESCE($n, column) for each patchable column WHERE id = $9
+func patchLocation(db *database, args []driver.Value) (*result, error) {
+	r := db.table("location").get(args[8])
+	if r == nil {
+		return project(nil, locationColumns), nil
+	}
+	for i, column := range locationColumns[1:9] {
+		if args[i] != nil {
+			r[column] = args[i]
+		}
+	}
+	return project([]row{r}, locationColumns), nil
+}
+
+// UPDATE location set geo = $1 WHERE id = $2
+func updateLocationGeo(db *database, args []driver.Value) (*result, error) {
+	return update("location", []string{"geo"}, locationColumns)(db, []driver.Value{args[1], args[0]})
+}
+
+// A spatial predicate between the geometry of a row and a query geometry
+type predicate func(column, query *shape) (bool, error)
+
+func intersectsPredicate(column, query *shape) (bool, error) {
+	return intersects(column, query), checkSRIDs(column, query)
+}
+
+func containsPredicate(column, query *shape) (bool, error) {
+	return within(query, column), checkSRIDs(column, query)
+}
+
+func withinPredicate(column, query *shape) (bool, error) {
+	return within(column, query), checkSRIDs(column, query)
+}
+
+func boxPredicate(column, query *shape) (bool, error) {
+	return boxesIntersect(column, query), checkSRIDs(column, query)
+}
+
+// Match rows where the geometry column satisfies the predicate with the query geometry.
+// Rows with NULL geometry never match.
+func matchShape(column string, query *shape, pred predicate) func(row) (bool, error) {
+	return func(r row) (bool, error) {
+		s, err := decodeShape(r[column])
+		if err != nil || s == nil || query == nil {
+			return false, err
+		}
+		return pred(s, query)
+	}
+}
+
+// SELECT WHERE the predicate holds for the column and $3 ORDER BY id LIMIT $1 OFFSET $2
+func spatial(tableName string, column string, returning []string, pred predicate) handler {
+	return func(db *database, args []driver.Value) (*result, error) {
+		query, err := decodeShape(args[2])
+		if err != nil {
+			return nil, err
+		}
+		return list(tableName, returning, matchShape(column, query, pred))(db, args)
+	}
+}
+
+// SELECT WHERE ST_DWithin(column, $3, $4) ORDER BY id LIMIT $1 OFFSET $2
+func withinDistance(tableName string, column string, returning []string) handler {
+	return func(db *database, args []driver.Value) (*result, error) {
+		query, err := decodeShape(args[2])
+		if err != nil {
+			return nil, err
+		}
+		limit, ok := toFloat(args[3])
+		if !ok {
+			return nil, fmt.Errorf("fakedb: distance of type %T", args[3])
+		}
+		return list(tableName, returning, matchShape(column, query, func(s, q *shape) (bool, error) {
+			return distance(s, q) <= limit, checkSRIDs(s, q)
+		}))(db, args)
+	}
+}
+
+// SELECT WHERE ST_DWithin(column::geography, $3, $4) ORDER BY id LIMIT $1 OFFSET $2,
+// with the distance in metres as a further column if withDistance is set
+func withinMetres(tableName string, column string, returning []string, withDistance bool) handler {
+	return func(db *database, args []driver.Value) (*result, error) {
+		query, err := decodeShape(args[2])
+		if err != nil {
+			return nil, err
+		}
+		limit, ok := toFloat(args[3])
+		if !ok {
+			return nil, fmt.Errorf("fakedb: distance of type %T", args[3])
+		}
+
+		rows, err := db.table(tableName).filter(nil)
+		if err != nil {
+			return nil, err
+		}
+		var matched []row
+		for _, r := range rows {
+			s, err := decodeShape(r[column])
+			if err != nil {
+				return nil, err
+			}
+			if s == nil || query == nil {
+				continue
+			}
+			d, err := metres(s, query)
+			if err != nil {
+				return nil, err
+			}
+			if d <= limit {
+				matched = append(matched, r.with("distance", d))
+			}
+		}
+
+		matched = page(matched, args[0], args[1])
+		if withDistance {
+			return project(matched, returning, "distance"), nil
+		}
+		return project(matched, returning), nil
+	}
+}
+
+// Get rows nearest the point with their distances, ordered by distance and id,
+// following the keyset (afterDistance, afterID) if set
+func nearest(db *database, tableName string, point, afterDistance, afterID, count driver.Value, match func(row) (bool, error)) ([]row, error) {
+	query, err := decodeShape(point)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.table(tableName).filter(match)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []row
+	for _, r := range rows {
+		s, err := decodeShape(r["geo"])
+		if err != nil {
+			return nil, err
+		}
+		if s == nil || query == nil {
+			continue
+		}
+		if err := checkSRIDs(s, query); err != nil {
+			return nil, err
+		}
+		d := distance(s, query)
+		if after, ok := afterDistance.(float64); ok {
+			id, _ := afterID.(int64)
+			if d < after || (d == after && r["id"].(int64) <= id) {
+				continue
+			}
+		}
+		matched = append(matched, r.with("distance", d))
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		di, dj := matched[i]["distance"].(float64), matched[j]["distance"].(float64)
+		return di < dj || (di == dj && matched[i]["id"].(int64) < matched[j]["id"].(int64))
+	})
+	return page(matched, count, int64(0)), nil
+}
+
+func listNearestLocations(db *database, args []driver.Value) (*result, error) {
+	var match func(row) (bool, error)
+	if args[1] != nil {
+		match = equals("organisation_id", args[1])
+	}
+	rows, err := nearest(db, "location", args[0], args[2], args[3], args[4], match)
+	if err != nil {
+		return nil, err
+	}
+	return project(rows, locationColumns, "distance"), nil
+}
+
+func listNearestGISData(db *database, args []driver.Value) (*result, error) {
+	rows, err := nearest(db, "gisdata", args[0], args[1], args[2], args[3], nil)
+	if err != nil {
+		return nil, err
+	}
+	return project(rows, []string{"id", "geo"}, "distance"), nil
+}
+
+func listOrganisationLocationsWithin(db *database, args []driver.Value) (*result, error) {
+	query, err := decodeShape(args[3])
+	if err != nil {
+		return nil, err
+	}
+	inOrganisation := equals("organisation_id", args[2])
+	isWithin := matchShape("geo", query, withinPredicate)
+	return list("location", locationColumns, func(r row) (bool, error) {
+		if ok, _ := inOrganisation(r); !ok {
+			return false, nil
+		}
+		return isWithin(r)
+	})(db, args)
+}
+
+// Get the extent of the geometry column of the rows as box2d text, or NULL
+func extent(rows []row, column string) (*result, error) {
+	box := geoBox{}
+	for _, r := range rows {
+		s, err := decodeShape(r[column])
+		if err != nil {
+			return nil, err
+		}
+		if s == nil {
+			continue
+		}
+		if b, ok := s.bounds(); ok {
+			box.expand(b.MinX, b.MinY, b.MaxX, b.MaxY)
+		}
+	}
+	res := &result{columns: []string{"extent"}, affected: 1}
+	res.rows = [][]driver.Value{{box.value()}}
+	return res, nil
+}
+
+func getLocationBox(db *database, args []driver.Value) (*result, error) {
+	r := db.table("location").get(args[0])
+	if r == nil {
+		return &result{columns: []string{"box"}}, nil
+	}
+	return extent([]row{r}, "geo")
+}
+
+func getOrganisationLocationsExtent(db *database, args []driver.Value) (*result, error) {
+	rows, err := db.table("location").filter(equals("organisation_id", args[0]))
+	if err != nil {
+		return nil, err
+	}
+	return extent(rows, "geo")
+}
+
+func getGISDataExtent(db *database, args []driver.Value) (*result, error) {
+	rows, err := db.table("gisdata").filter(nil)
+	if err != nil {
+		return nil, err
+	}
+	return extent(rows, "geo")
+}
+
+// Accumulates the bounds of geometry as ST_Extent does
+type geoBox struct {
+	valid                  bool
+	minX, minY, maxX, maxY float64
+}
+
+func (b *geoBox) expand(minX, minY, maxX, maxY float64) {
+	if !b.valid {
+		*b = geoBox{valid: true, minX: minX, minY: minY, maxX: maxX, maxY: maxY}
+		return
+	}
+	b.minX, b.minY = math.Min(b.minX, minX), math.Min(b.minY, minY)
+	b.maxX, b.maxY = math.Max(b.maxX, maxX), math.Max(b.maxY, maxY)
+}
+
+// Get the box as box2d text, or NULL if nothing was bounded
+func (b geoBox) value() driver.Value {
+	if !b.valid {
+		return nil
+	}
+	return fmt.Sprintf("BOX(%v %v,%v %v)", b.minX, b.minY, b.maxX, b.maxY)
+}
+
+// Get a copy of the row with an additional computed column
+func (r row) with(column string, value driver.Value) row {
+	c := make(row, len(r)+1)
+	for k, v := range r {
+		c[k] = v
+	}
+	c[column] = value
+	return c
+}
+
+// Convert a numeric argument to float64
+func toFloat(value driver.Value) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,345 @@
+package fakedb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+// A planar shape flattened from a geometry, for evaluating spatial predicates
+type shape struct {
+	srid     uint32
+	points   [][2]float64
+	lines    [][][2]float64
+	polygons [][][][2]float64 // rings of each polygon, shell first
+}
+
+// Decode a hex EWKB geometry value into a shape. NULL is returned as nil.
+func decodeShape(value driver.Value) (*shape, error) {
+	if value == nil {
+		return nil, nil
+	}
+	var hexewkb []byte
+	switch v := value.(type) {
+	case []byte:
+		hexewkb = v
+	case string:
+		hexewkb = []byte(v)
+	default:
+		return nil, fmt.Errorf("fakedb: geometry value of type %T", value)
+	}
+
+	var g geo.GISGeometry
+	if err := g.Scan(hexewkb); err != nil {
+		return nil, err
+	}
+	s := &shape{srid: g.SRID}
+	if err := s.add(g.Geometry); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add the coordinates of a geometry to the shape
+func (s *shape) add(g geo.GeometrySubtype) error {
+	switch t := g.(type) {
+	case *geo.Point:
+		if len(t.Coords) >= 2 && !math.IsNaN(t.Coords[0]) {
+			s.points = append(s.points, [2]float64{t.Coords[0], t.Coords[1]})
+		}
+	case *geo.MultiPoint:
+		for i := 0; i < t.NumPoints(); i++ {
+			c := t.Coord(i)
+			s.points = append(s.points, [2]float64{c[0], c[1]})
+		}
+	case *geo.LineString:
+		s.lines = append(s.lines, xys(t.NumPoints(), t.Coord))
+	case *geo.MultiLineString:
+		for i := range t.LineStrings {
+			s.add(&t.LineStrings[i])
+		}
+	case *geo.Polygon:
+		var rings [][][2]float64
+		for _, ring := range t.LinearRings {
+			rings = append(rings, xys(ring.NumPoints(), ring.Coord))
+		}
+		if len(rings) > 0 {
+			s.polygons = append(s.polygons, rings)
+		}
+	case *geo.Triangle:
+		s.polygons = append(s.polygons, [][][2]float64{xys(t.NumPoints(), t.Coord)})
+	case *geo.MultiPolygon:
+		for i := range t.Polygons {
+			s.add(&t.Polygons[i])
+		}
+	case *geo.GeometryCollection:
+		for _, member := range t.Geometry {
+			if err := s.add(member); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%w spatial predicate on %T", ErrUnsupported, g)
+	}
+	return nil
+}
+
+// Get the XY coordinates of a point sequence
+func xys(n int, coord func(int) []float64) [][2]float64 {
+	out := make([][2]float64, n)
+	for i := range out {
+		c := coord(i)
+		out[i] = [2]float64{c[0], c[1]}
+	}
+	return out
+}
+
+// Location of a point relative to a shape
+type location int
+
+const (
+	exterior location = iota
+	boundary
+	interior
+)
+
+// Call fn with each vertex of the shape
+func (s *shape) eachVertex(fn func(p [2]float64)) {
+	for _, p := range s.points {
+		fn(p)
+	}
+	for _, line := range s.lines {
+		for _, p := range line {
+			fn(p)
+		}
+	}
+	for _, polygon := range s.polygons {
+		for _, ring := range polygon {
+			for _, p := range ring {
+				fn(p)
+			}
+		}
+	}
+}
+
+// Call fn with each segment of the lines and polygon rings of the shape
+func (s *shape) eachSegment(fn func(a, b [2]float64)) {
+	for _, line := range s.lines {
+		for i := 1; i < len(line); i++ {
+			fn(line[i-1], line[i])
+		}
+	}
+	for _, polygon := range s.polygons {
+		for _, ring := range polygon {
+			for i := 1; i < len(ring); i++ {
+				fn(ring[i-1], ring[i])
+			}
+		}
+	}
+}
+
+// Locate a point relative to the shape. Points on lines and at points of the
+// shape are treated as interior.
+func (s *shape) locate(p [2]float64) location {
+	for _, q := range s.points {
+		if p == q {
+			return interior
+		}
+	}
+	for _, line := range s.lines {
+		for i := 1; i < len(line); i++ {
+			if onSegment(p, line[i-1], line[i]) {
+				return interior
+			}
+		}
+	}
+	result := exterior
+	for _, polygon := range s.polygons {
+		switch locateInPolygon(p, polygon) {
+		case interior:
+			return interior
+		case boundary:
+			result = boundary
+		}
+	}
+	return result
+}
+
+// Locate a point relative to a polygon
+func locateInPolygon(p [2]float64, rings [][][2]float64) location {
+	for _, ring := range rings {
+		for i := 1; i < len(ring); i++ {
+			if onSegment(p, ring[i-1], ring[i]) {
+				return boundary
+			}
+		}
+	}
+	if !inRing(p, rings[0]) {
+		return exterior
+	}
+	for _, hole := range rings[1:] {
+		if inRing(p, hole) {
+			return exterior
+		}
+	}
+	return interior
+}
+
+// Report whether a point not on the ring is inside it, by ray casting
+func inRing(p [2]float64, ring [][2]float64) bool {
+	inside := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if (a[1] > p[1]) != (b[1] > p[1]) && p[0] < (b[0]-a[0])*(p[1]-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Get the cross product of (b - a) and (c - a)
+func cross(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+// Report whether the point lies on the segment
+func onSegment(p, a, b [2]float64) bool {
+	return cross(a, b, p) == 0 &&
+		math.Min(a[0], b[0]) <= p[0] && p[0] <= math.Max(a[0], b[0]) &&
+		math.Min(a[1], b[1]) <= p[1] && p[1] <= math.Max(a[1], b[1])
+}
+
+// Report whether two segments intersect, including touching
+func segmentsIntersect(a, b, c, d [2]float64) bool {
+	d1, d2 := cross(c, d, a), cross(c, d, b)
+	d3, d4 := cross(a, b, c), cross(a, b, d)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return onSegment(a, c, d) || onSegment(b, c, d) || onSegment(c, a, b) || onSegment(d, a, b)
+}
+
+// Check the shapes can be compared, as PostGIS does
+func checkSRIDs(a, b *shape) error {
+	if a.srid != b.srid {
+		return fmt.Errorf("fakedb: operation on mixed SRID geometries (%v and %v)", a.srid, b.srid)
+	}
+	return nil
+}
+
+// ST_Intersects - the shapes share any point
+func intersects(a, b *shape) bool {
+	found := false
+	a.eachVertex(func(p [2]float64) { found = found || b.locate(p) != exterior })
+	b.eachVertex(func(p [2]float64) { found = found || a.locate(p) != exterior })
+	if found {
+		return true
+	}
+	a.eachSegment(func(p, q [2]float64) {
+		b.eachSegment(func(r, s [2]float64) { found = found || segmentsIntersect(p, q, r, s) })
+	})
+	return found
+}
+
+// ST_Within - a lies inside b, and shares some interior point with it. This is
+// evaluated on the vertices and segment midpoints of a and the vertex average
+// of its polygons, which is exact for points and convex polygons.
+func within(a, b *shape) bool {
+	inside, outside := false, false
+	check := func(p [2]float64) {
+		switch b.locate(p) {
+		case interior:
+			inside = true
+		case exterior:
+			outside = true
+		}
+	}
+	a.eachVertex(check)
+	a.eachSegment(func(p, q [2]float64) { check([2]float64{(p[0] + q[0]) / 2, (p[1] + q[1]) / 2}) })
+	for _, polygon := range a.polygons {
+		if c, ok := innerPoint(polygon); ok {
+			check(c)
+		}
+	}
+	return inside && !outside
+}
+
+// Get a point in the interior of a polygon, if the average of its shell
+// vertices is one
+func innerPoint(rings [][][2]float64) ([2]float64, bool) {
+	shell := rings[0]
+	if len(shell) < 2 {
+		return [2]float64{}, false
+	}
+	var c [2]float64
+	for _, p := range shell[1:] {
+		c[0] += p[0]
+		c[1] += p[1]
+	}
+	n := float64(len(shell) - 1)
+	c = [2]float64{c[0] / n, c[1] / n}
+	return c, locateInPolygon(c, rings) == interior
+}
+
+// ST_Distance - the minimum planar distance between the shapes
+func distance(a, b *shape) float64 {
+	if intersects(a, b) {
+		return 0
+	}
+	d := math.Inf(1)
+	a.eachVertex(func(p [2]float64) {
+		b.eachVertex(func(q [2]float64) { d = math.Min(d, math.Hypot(p[0]-q[0], p[1]-q[1])) })
+		b.eachSegment(func(q, r [2]float64) { d = math.Min(d, segmentDistance(p, q, r)) })
+	})
+	b.eachVertex(func(p [2]float64) {
+		a.eachSegment(func(q, r [2]float64) { d = math.Min(d, segmentDistance(p, q, r)) })
+	})
+	return d
+}
+
+// Get the distance from a point to a segment
+func segmentDistance(p, a, b [2]float64) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p[0]-a[0], p[1]-a[1])
+	}
+	t := ((p[0]-a[0])*dx + (p[1]-a[1])*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p[0]-(a[0]+t*dx), p[1]-(a[1]+t*dy))
+}
+
+// Get the 2D bounds of the shape, and whether it has any vertices
+func (s *shape) bounds() (box geo.Box2D, ok bool) {
+	box = geo.Box2D{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+	s.eachVertex(func(p [2]float64) {
+		ok = true
+		box.MinX, box.MaxX = math.Min(box.MinX, p[0]), math.Max(box.MaxX, p[0])
+		box.MinY, box.MaxY = math.Min(box.MinY, p[1]), math.Max(box.MaxY, p[1])
+	})
+	return box, ok
+}
+
+// && - the bounding boxes of the shapes intersect
+func boxesIntersect(a, b *shape) bool {
+	ba, okA := a.bounds()
+	bb, okB := b.bounds()
+	return okA && okB && ba.MinX <= bb.MaxX && bb.MinX <= ba.MaxX && ba.MinY <= bb.MaxY && bb.MinY <= ba.MaxY
+}
+
+// Mean radius of the earth in metres, used for geography distances
+const earthRadius = 6371008.8
+
+// ST_Distance on geography - the great circle distance in metres between two
+// points. PostGIS measures on the spheroid, so distances differ slightly.
+func metres(a, b *shape) (float64, error) {
+	if len(a.points) != 1 || len(b.points) != 1 || a.lines != nil || b.lines != nil || a.polygons != nil || b.polygons != nil {
+		return 0, fmt.Errorf("%w geography distance between non-point geometries", ErrUnsupported)
+	}
+	p, q := a.points[0], b.points[0]
+	lat1, lat2 := p[1]*math.Pi/180, q[1]*math.Pi/180
+	dLat, dLon := lat2-lat1, (q[0]-p[0])*math.Pi/180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h))), nil
+}
@@ -0,0 +1,100 @@
+package fakedb
+
+import (
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestSpatialPredicates(t *testing.T) {
+	square := makeTestShape(t, 4326, makeTestPolygon(t, 0, 0, 0, 2, 2, 2, 2, 0, 0, 0))
+	inside := makeTestShape(t, 4326, makeTestPoint(t, 1, 1))
+	edge := makeTestShape(t, 4326, makeTestPoint(t, 0, 1))
+	outside := makeTestShape(t, 4326, makeTestPoint(t, 3, 1))
+	crossing := makeTestShape(t, 4326, makeTestLineString(t, -1, 1, 3, 1))
+
+	tests := []struct {
+		name     string
+		got      bool
+		expected bool
+	}{
+		{"inside intersects", intersects(inside, square), true},
+		{"edge intersects", intersects(edge, square), true},
+		{"outside intersects", intersects(outside, square), false},
+		{"crossing intersects", intersects(crossing, square), true},
+		{"inside within", within(inside, square), true},
+		{"edge within", within(edge, square), false},
+		{"crossing within", within(crossing, square), false},
+		{"square within", within(square, square), true},
+		{"outside box", boxesIntersect(outside, square), false},
+		{"crossing box", boxesIntersect(crossing, square), true},
+	}
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("%v: expected %v, got %v", test.name, test.expected, test.got)
+		}
+	}
+
+	if d := distance(outside, square); d != 1 {
+		t.Errorf("expected distance 1 from %v to square, got %v", outside.points, d)
+	}
+	if err := checkSRIDs(inside, makeTestShape(t, 27700, makeTestPoint(t, 1, 1))); err == nil {
+		t.Error("shapes with mixed SRIDs did not return an error")
+	}
+}
+
+func TestMetres(t *testing.T) {
+	london := makeTestShape(t, 4326, makeTestPoint(t, -0.1276, 51.5072))
+	paris := makeTestShape(t, 4326, makeTestPoint(t, 2.3522, 48.8566))
+
+	d, err := metres(london, paris)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d < 343000 || d > 345000 {
+		t.Errorf("expected London to Paris around 344km, got %vm", d)
+	}
+}
+
+// Create a shape from a geometry with the given SRID
+func makeTestShape(t *testing.T, srid uint32, g geo.GeometrySubtype) *shape {
+	gis := geo.NewGISGeometry(g)
+	gis.SetSRID(srid)
+	value, err := gis.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := decodeShape(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func makeTestPoint(t *testing.T, x, y float64) *geo.Point {
+	p, err := geo.NewPoint([]float64{x, y}, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func makeTestLineString(t *testing.T, coords ...float64) *geo.LineString {
+	l, err := geo.LineStringFromCoords(coords, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func makeTestPolygon(t *testing.T, coords ...float64) *geo.Polygon {
+	r, err := geo.LinearRingFromCoords(coords, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := geo.NewPolygon([]geo.LinearRing{*r})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
@@ -0,0 +1,165 @@
+package fakedb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// An in-memory database of tables by name
+type database struct {
+	mu     sync.Mutex
+	tables map[string]*table
+}
+
+func newDatabase() *database {
+	return &database{tables: map[string]*table{}}
+}
+
+// Get the table with the given name, creating it if necessary
+func (db *database) table(name string) *table {
+	t, ok := db.tables[name]
+	if !ok {
+		t = &table{rows: map[int64]row{}}
+		db.tables[name] = t
+	}
+	return t
+}
+
+// Get a copy of all tables
+func (db *database) copyTables() map[string]*table {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	tables := make(map[string]*table, len(db.tables))
+	for name, t := range db.tables {
+		tables[name] = t.copy()
+	}
+	return tables
+}
+
+// Replace all tables
+func (db *database) restoreTables(tables map[string]*table) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.tables = tables
+}
+
+// A row of column values by column name. Rows always have an id column.
+type row map[string]driver.Value
+
+// A table of rows by id, with ids assigned as by a bigserial primary key
+type table struct {
+	lastID int64
+	rows   map[int64]row
+}
+
+func (t *table) copy() *table {
+	c := &table{lastID: t.lastID, rows: make(map[int64]row, len(t.rows))}
+	for id, r := range t.rows {
+		rc := make(row, len(r))
+		for k, v := range r {
+			rc[k] = v
+		}
+		c.rows[id] = rc
+	}
+	return c
+}
+
+// Insert a row with the next id, setting the columns from the values in order.
+// A created_at column is set to the current time.
+func (t *table) insert(columns []string, values []driver.Value, createdAt bool) (row, error) {
+	if len(values) != len(columns) {
+		return nil, fmt.Errorf("fakedb: insert of %v columns given %v values", len(columns), len(values))
+	}
+	t.lastID++
+	r := row{"id": t.lastID}
+	for i, c := range columns {
+		r[c] = values[i]
+	}
+	if createdAt {
+		r["created_at"] = time.Now().UTC()
+	}
+	t.rows[t.lastID] = r
+	return r, nil
+}
+
+// Get the row with the given id, or nil if there is none
+func (t *table) get(id driver.Value) row {
+	i, ok := id.(int64)
+	if !ok {
+		return nil
+	}
+	return t.rows[i]
+}
+
+// Set the columns of a row from the values in order
+func (r row) set(columns []string, values []driver.Value) error {
+	if len(values) != len(columns) {
+		return fmt.Errorf("fakedb: update of %v columns given %v values", len(columns), len(values))
+	}
+	for i, c := range columns {
+		r[c] = values[i]
+	}
+	return nil
+}
+
+// Get the rows matching the filter in id order. A nil filter matches all rows.
+func (t *table) filter(match func(row) (bool, error)) ([]row, error) {
+	ids := make([]int64, 0, len(t.rows))
+	for id := range t.rows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var rows []row
+	for _, id := range ids {
+		r := t.rows[id]
+		if match != nil {
+			ok, err := match(r)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		rows = append(rows, r)
+	}
+	return rows, nil
+}
+
+// The columns and rows returned by a query, and the number of rows affected
+type result struct {
+	columns  []string
+	rows     [][]driver.Value
+	affected int64
+}
+
+// Create a result with the given columns of the rows. Extra values computed
+// for each row, such as distances, may be appended as further columns.
+func project(rows []row, columns []string, extra ...string) *result {
+	res := &result{columns: append(append([]string(nil), columns...), extra...), affected: int64(len(rows))}
+	for _, r := range rows {
+		values := make([]driver.Value, 0, len(res.columns))
+		for _, c := range res.columns {
+			values = append(values, r[c])
+		}
+		res.rows = append(res.rows, values)
+	}
+	return res
+}
+
+// Apply LIMIT and OFFSET to rows
+func page(rows []row, limit driver.Value, offset driver.Value) []row {
+	o, _ := offset.(int64)
+	if o >= int64(len(rows)) {
+		return nil
+	}
+	rows = rows[o:]
+	if l, ok := limit.(int64); ok && l < int64(len(rows)) {
+		rows = rows[:l]
+	}
+	return rows
+}
@@ -0,0 +1,4 @@
+CREATE TABLE "geogdata" (
+  "id" bigserial PRIMARY KEY,
+  "geog" geography NOT NULL
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS gisdata_geo_idx;
+DROP INDEX IF EXISTS location_geo_idx;
+
+ALTER TABLE "gisdata" ALTER COLUMN "geo" TYPE geometry, ALTER COLUMN "geo" DROP NOT NULL;
+ALTER TABLE "location" ALTER COLUMN "geo" TYPE geometry, ALTER COLUMN "geo" DROP NOT NULL;
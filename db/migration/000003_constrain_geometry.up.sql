@@ -0,0 +1,15 @@
+-- Geometry without an SRID is assumed to be WGS 84; any other SRID fails the cast.
+-- Geometry is required, as the Go side checks before insert and COPY.
+ALTER TABLE "location"
+  ALTER COLUMN "geo" TYPE geometry(Point, 4326)
+  USING CASE WHEN ST_SRID("geo") = 0 THEN ST_SetSRID("geo", 4326) ELSE "geo" END,
+  ALTER COLUMN "geo" SET NOT NULL;
+
+ALTER TABLE "gisdata"
+  ALTER COLUMN "geo" TYPE geometry(Geometry, 4326)
+  USING CASE WHEN ST_SRID("geo") = 0 THEN ST_SetSRID("geo", 4326) ELSE "geo" END,
+  ALTER COLUMN "geo" SET NOT NULL;
+
+CREATE INDEX "location_geo_idx" ON "location" USING GIST ("geo");
+
+CREATE INDEX "gisdata_geo_idx" ON "gisdata" USING GIST ("geo");
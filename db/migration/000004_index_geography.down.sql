@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS geogdata_geog_idx;
+DROP INDEX IF EXISTS gisdata_geog_idx;
+DROP INDEX IF EXISTS location_geog_idx;
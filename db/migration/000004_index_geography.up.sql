@@ -0,0 +1,7 @@
+-- Metre based queries cast geometry to geography, which can not use the
+-- geometry indexes, so the casts are indexed as well
+CREATE INDEX "location_geog_idx" ON "location" USING GIST (("geo"::geography));
+
+CREATE INDEX "gisdata_geog_idx" ON "gisdata" USING GIST (("geo"::geography));
+
+CREATE INDEX "geogdata_geog_idx" ON "geogdata" USING GIST ("geog");
@@ -0,0 +1,120 @@
+-- name: CreateBookable :one
+INSERT INTO bookable (
+  location_id, capacity, description, default_booking_length, custom_booking_length, clear_time
+) VALUES (
+  sqlc.arg(location_id),
+  sqlc.arg(capacity),
+  sqlc.narg(description),
+  make_interval(secs => sqlc.narg(default_booking_length_seconds)::FLOAT8),
+  sqlc.arg(custom_booking_length),
+  make_interval(secs => sqlc.narg(clear_time_seconds)::FLOAT8)
+)
+RETURNING id;
+
+-- name: CreateBookingLength :one
+INSERT INTO booking_lengths (
+  bookable_id, default_booking_length
+) VALUES (
+  sqlc.arg(bookable_id),
+  make_interval(secs => sqlc.arg(booking_length_seconds)::FLOAT8)
+)
+RETURNING id;
+
+-- name: GetBookableForUpdate :one
+SELECT id, location_id, capacity, custom_booking_length
+FROM bookable
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetBookableAvailability :one
+SELECT
+  b.id,
+  (b.capacity - COALESCE((
+    -- Peak usage, which is reached at the window start or a booking start within it
+    SELECT MAX(used)
+    FROM (
+      SELECT SUM(k.capacity) AS used
+      FROM (
+        SELECT sqlc.arg(start_time)::timestamptz AS instant
+        UNION
+        SELECT s.start_time
+        FROM booking s
+        WHERE s.bookable_id = b.id
+          AND s.start_time <@ tstzrange(sqlc.arg(start_time)::timestamptz, sqlc.arg(end_time)::timestamptz + COALESCE(b.clear_time, '0'::interval))
+      ) t
+      JOIN booking k ON k.bookable_id = b.id
+        AND tstzrange(k.start_time, k.end_time + COALESCE(b.clear_time, '0'::interval)) @> t.instant
+      GROUP BY t.instant
+    ) usages
+  ), 0))::BIGINT AS free_capacity,
+  (b.custom_booking_length
+    OR (b.default_booking_length IS NULL AND NOT EXISTS (
+      SELECT 1 FROM booking_lengths bl WHERE bl.bookable_id = b.id))
+    OR b.default_booking_length = sqlc.arg(end_time)::timestamptz - sqlc.arg(start_time)::timestamptz
+    OR EXISTS (
+      SELECT 1 FROM booking_lengths bl
+      WHERE bl.bookable_id = b.id
+        AND bl.default_booking_length = sqlc.arg(end_time)::timestamptz - sqlc.arg(start_time)::timestamptz)
+  )::BOOLEAN AS length_allowed
+FROM bookable b
+WHERE b.id = sqlc.arg(bookable_id);
+
+-- name: ListAvailableBookablesNear :many
+SELECT id, location_id, capacity, description, distance, free_capacity
+FROM (
+  SELECT
+    b.id, b.location_id, b.capacity, b.description,
+    ST_Distance(l.geo::geography, sqlc.arg(point)::geography)::FLOAT8 AS distance,
+    (b.capacity - COALESCE((
+      -- Peak usage, which is reached at the window start or a booking start within it
+      SELECT MAX(used)
+      FROM (
+        SELECT SUM(k.capacity) AS used
+        FROM (
+          SELECT sqlc.arg(start_time)::timestamptz AS instant
+          UNION
+          SELECT s.start_time
+          FROM booking s
+          WHERE s.bookable_id = b.id
+            AND s.start_time <@ tstzrange(sqlc.arg(start_time)::timestamptz, sqlc.arg(end_time)::timestamptz + COALESCE(b.clear_time, '0'::interval))
+        ) t
+        JOIN booking k ON k.bookable_id = b.id
+          AND tstzrange(k.start_time, k.end_time + COALESCE(b.clear_time, '0'::interval)) @> t.instant
+        GROUP BY t.instant
+      ) usages
+    ), 0))::BIGINT AS free_capacity
+  FROM bookable b
+  JOIN location l ON l.id = b.location_id
+  WHERE
+    ST_DWithin(l.geo::geography, sqlc.arg(point)::geography, sqlc.arg(metres)::FLOAT8)
+    AND (b.custom_booking_length
+      OR (b.default_booking_length IS NULL AND NOT EXISTS (
+        SELECT 1 FROM booking_lengths bl WHERE bl.bookable_id = b.id))
+      OR b.default_booking_length = sqlc.arg(end_time)::timestamptz - sqlc.arg(start_time)::timestamptz
+      OR EXISTS (
+        SELECT 1 FROM booking_lengths bl
+        WHERE bl.bookable_id = b.id
+          AND bl.default_booking_length = sqlc.arg(end_time)::timestamptz - sqlc.arg(start_time)::timestamptz))
+) available
+WHERE free_capacity >= sqlc.arg(capacity)::BIGINT
+ORDER BY distance, id
+LIMIT sqlc.arg(count);
+
+-- name: CreateBooking :one
+INSERT INTO booking (
+  bookable_id, booked_by, start_time, end_time, capacity
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListBookableBookings :many
+SELECT * FROM booking
+WHERE bookable_id = sqlc.arg(bookable_id)
+  AND start_time < sqlc.arg(end_time)::timestamptz
+  AND end_time > sqlc.arg(start_time)::timestamptz
+ORDER BY start_time, id;
+
+-- name: DeleteBooking :exec
+DELETE FROM booking
+WHERE id = $1;
@@ -0,0 +1,65 @@
+-- name: CreateGeogData :one
+INSERT INTO geogdata (
+  geog
+) VALUES (
+  sqlc.arg(geog)::geography
+)
+RETURNING id, geog::geography;
+
+-- name: GetAllGeogData :many
+SELECT geog::geography
+FROM geogdata;
+
+-- name: GetGeogData :one
+SELECT geog::geography
+FROM geogdata
+WHERE id = $1 LIMIT 1;
+
+-- name: ListGeogDataWithinDistance :many
+SELECT geog::geography
+FROM geogdata
+WHERE
+  ST_DWithin(
+    geog::geography,
+    sqlc.arg(geog)::geography,
+    sqlc.arg(metres)::FLOAT8)
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: ListLocationsWithinMetres :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at,
+		ST_Distance(geo::geography, sqlc.arg(location)::geography)::FLOAT8 AS distance
+FROM location
+WHERE
+  ST_DWithin(
+    geo::geography,
+    sqlc.arg(location)::geography,
+    sqlc.arg(metres)::FLOAT8)
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: ListGISDataWithinMetres :many
+SELECT geo::geometry
+FROM gisdata
+WHERE
+  ST_DWithin(
+    geo::geography,
+    sqlc.arg(location)::geography,
+    sqlc.arg(metres)::FLOAT8)
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: DeleteGeogData :exec
+DELETE FROM geogdata
+WHERE id = $1;
@@ -15,6 +15,42 @@ SELECT geo::geometry
 FROM gisdata
 WHERE id = $1 LIMIT 1;
 
+-- name: GetGISDataExtent :one
+SELECT ST_Extent(geo::geometry)::box2d AS extent
+FROM gisdata;
+
+-- name: ListGISDataContaining :many
+SELECT id, geo::geometry
+FROM gisdata
+WHERE ST_Contains(geo, sqlc.arg(geo)::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: ListGISDataInBox :many
+SELECT id, geo::geometry
+FROM gisdata
+WHERE geo && sqlc.arg(geo)::geometry
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: ListGISDataIntersecting :many
+SELECT id, geo::geometry
+FROM gisdata
+WHERE ST_Intersects(geo, sqlc.arg(geo)::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: ListGISDataWithin :many
+SELECT id, geo::geometry
+FROM gisdata
+WHERE ST_Within(geo, sqlc.arg(geo)::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
 -- name: ListGISDataWithinDistance :many
 SELECT geo::geometry 
 FROM gisdata
@@ -27,6 +63,18 @@ ORDER BY id
 LIMIT $1
 OFFSET $2;
 
+-- name: ListNearestGISData :many
+SELECT id, geo::geometry, (geo <-> sqlc.arg(point)::geometry)::FLOAT8 AS distance
+FROM gisdata
+WHERE
+  geo IS NOT NULL
+  AND (
+    sqlc.narg(after_distance)::FLOAT8 IS NULL
+    OR (geo <-> sqlc.arg(point)::geometry) > sqlc.narg(after_distance)::FLOAT8
+    OR ((geo <-> sqlc.arg(point)::geometry) = sqlc.narg(after_distance)::FLOAT8 AND id > sqlc.narg(after_id)::BIGINT))
+ORDER BY geo <-> sqlc.arg(point)::geometry, id
+LIMIT sqlc.arg(count);
+
 -- name: DeleteGISData :exec
 DELETE FROM gisdata
 WHERE id = $1;
\ No newline at end of file
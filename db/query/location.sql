@@ -1,14 +1,14 @@
 -- name: CreateLocation :one
 INSERT INTO location (
-  full_name, geo
+  organisation_id, user_id, full_name, line1, line2, city, county, country_code, geo
 ) VALUES (
-  $1, sqlc.arg(geo)::geometry
+  $1, $2, $3, $4, $5, $6, $7, $8, sqlc.arg(geo)::geometry
 )
 RETURNING *;
 
 -- name: GetLocations :many
 SELECT id, organisation_id, user_id, full_name,
-		line1
+		line1,
 		line2,
 		city,
 		county,
@@ -20,7 +20,7 @@ FROM location;
 -- name: GetLocation :one
 SELECT 
 		id, organisation_id, user_id, full_name,
-		line1
+		line1,
 		line2,
 		city,
 		county,
@@ -34,7 +34,7 @@ WHERE id = $1 LIMIT 1;
 -- name: GetLocationForUpdate :one
 SELECT 
 		id, organisation_id, user_id, full_name,
-		line1
+		line1,
 		line2,
 		city,
 		county,
@@ -45,10 +45,115 @@ FROM location
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE;
 
+-- name: GetLocationBox :one
+SELECT Box2D(geo::geometry)::box2d AS box
+FROM location
+WHERE id = $1 LIMIT 1;
+
+-- name: GetOrganisationLocationsExtent :one
+SELECT ST_Extent(geo::geometry)::box2d AS extent
+FROM location
+WHERE organisation_id = $1;
+
+-- name: GetOrganisationLocations3DExtent :one
+SELECT ST_3DExtent(geo::geometry)::box3d AS extent
+FROM location
+WHERE organisation_id = $1;
+
+-- name: ListLocationsContaining :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at
+FROM location
+WHERE ST_Contains(geo, sqlc.arg(geo)::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: ListLocationsInBox :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at
+FROM location
+WHERE geo && sqlc.arg(geo)::geometry
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: ListLocationsIntersecting :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at
+FROM location
+WHERE ST_Intersects(geo, sqlc.arg(geo)::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: ListLocationsWithin :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at
+FROM location
+WHERE ST_Within(geo, sqlc.arg(geo)::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: ListOrganisationLocationsWithin :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at
+FROM location
+WHERE organisation_id = sqlc.arg(organisation_id) AND ST_Within(geo, sqlc.arg(geo)::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: GetOrganisationCoverage :one
+SELECT COALESCE(ST_Union(geo), 'SRID=4326;GEOMETRYCOLLECTION EMPTY'::geometry)::geometry AS coverage
+FROM location
+WHERE organisation_id = $1;
+
+-- name: GetOrganisationConvexHull :one
+SELECT COALESCE(ST_ConvexHull(ST_Collect(geo)), 'SRID=4326;GEOMETRYCOLLECTION EMPTY'::geometry)::geometry AS hull
+FROM location
+WHERE organisation_id = $1;
+
 -- name: ListLocationsWithinDistance :many
 SELECT 
 		id, organisation_id, user_id, full_name,
-		line1
+		line1,
 		line2,
 		city,
 		county,
@@ -65,6 +170,61 @@ ORDER BY id
 LIMIT $1
 OFFSET $2;
 
+-- name: ListNearestLocations :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at,
+		(geo <-> sqlc.arg(point)::geometry)::FLOAT8 AS distance
+FROM location
+WHERE
+  geo IS NOT NULL
+  AND (sqlc.narg(organisation_id)::BIGINT IS NULL OR organisation_id = sqlc.narg(organisation_id)::BIGINT)
+  AND (
+    sqlc.narg(after_distance)::FLOAT8 IS NULL
+    OR (geo <-> sqlc.arg(point)::geometry) > sqlc.narg(after_distance)::FLOAT8
+    OR ((geo <-> sqlc.arg(point)::geometry) = sqlc.narg(after_distance)::FLOAT8 AND id > sqlc.narg(after_id)::BIGINT))
+ORDER BY geo <-> sqlc.arg(point)::geometry, id
+LIMIT sqlc.arg(count);
+
+-- name: UpdateLocation :one
+UPDATE location
+  set organisation_id = $2,
+  user_id = $3,
+  full_name = $4,
+  line1 = $5,
+  line2 = $6,
+  city = $7,
+  county = $8,
+  country_code = $9,
+  geo = sqlc.arg(geo)::geometry
+WHERE id = $1
+RETURNING *;
+
+-- name: PatchLocation :one
+UPDATE location
+  set organisation_id = COALESCE(sqlc.narg(organisation_id), organisation_id),
+  user_id = COALESCE(sqlc.narg(user_id), user_id),
+  full_name = COALESCE(sqlc.narg(full_name), full_name),
+  line1 = COALESCE(sqlc.narg(line1), line1),
+  line2 = COALESCE(sqlc.narg(line2), line2),
+  city = COALESCE(sqlc.narg(city), city),
+  county = COALESCE(sqlc.narg(county), county),
+  country_code = COALESCE(sqlc.narg(country_code), country_code)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UpdateLocationGeo :one
+UPDATE location
+  set geo = sqlc.arg(geo)::geometry
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
 -- name: DeleteLocation :exec
 DELETE FROM location
 WHERE id = $1;
\ No newline at end of file
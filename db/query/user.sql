@@ -0,0 +1,50 @@
+-- name: CreateUser :one
+INSERT INTO users (
+  full_name, email, encrypted_password, mobile, organisation_id
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING id, full_name, email, mobile, organisation_id, created_at;
+
+-- name: GetUser :one
+SELECT id, full_name, email, mobile, organisation_id, created_at FROM users
+WHERE id = $1 LIMIT 1;
+
+-- name: GetUserByEmail :one
+SELECT id, full_name, email, mobile, organisation_id, created_at FROM users
+WHERE email = $1 LIMIT 1;
+
+-- name: GetUserCredentials :one
+SELECT id, encrypted_password FROM users
+WHERE email = $1 LIMIT 1;
+
+-- name: ListUsers :many
+SELECT id, full_name, email, mobile, organisation_id, created_at FROM users
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: ListOrganisationUsers :many
+SELECT id, full_name, email, mobile, organisation_id, created_at FROM users
+WHERE organisation_id = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateUser :one
+UPDATE users
+  set full_name = $2,
+  email = $3,
+  mobile = $4,
+  organisation_id = $5
+WHERE id = $1
+RETURNING id, full_name, email, mobile, organisation_id, created_at;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+  set encrypted_password = $2
+WHERE id = $1;
+
+-- name: DeleteUser :exec
+DELETE FROM users
+WHERE id = $1;
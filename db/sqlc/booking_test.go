@@ -0,0 +1,186 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestBookOverlapping(t *testing.T) {
+	store := NewStore(testDB)
+	bookableID, userID, _ := makeTestBookable(t, store, 1)
+
+	start := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+	book := func(from time.Time, length time.Duration) error {
+		_, err := store.Book(context.Background(), BookParams{
+			BookableID: bookableID,
+			BookedBy:   userID,
+			StartTime:  from,
+			EndTime:    from.Add(length),
+			Capacity:   1,
+		})
+		return err
+	}
+
+	if err := book(start, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		from   time.Time
+		length time.Duration
+		err    error
+	}{
+		{"overlapping", start.Add(30 * time.Minute), time.Hour, ErrBookingUnavailable},
+		{"within clear time", start.Add(time.Hour), time.Hour, ErrBookingUnavailable},
+		{"before with clear time", start.Add(-time.Hour), time.Hour, ErrBookingUnavailable},
+		{"disallowed length", start.Add(3 * time.Hour), 2 * time.Hour, ErrBookingLength},
+		{"after clear time", start.Add(75 * time.Minute), time.Hour, nil},
+		{"allowed length", start.Add(3 * time.Hour), 30 * time.Minute, nil},
+	}
+	for _, test := range tests {
+		err := book(test.from, test.length)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%v booking: expected error %v, got %v", test.name, test.err, err)
+		}
+	}
+
+	bookings, err := store.ListBookableBookings(context.Background(), ListBookableBookingsParams{
+		BookableID: sql.NullInt64{Int64: bookableID, Valid: true},
+		StartTime:  start,
+		EndTime:    start.Add(4 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bookings) != 3 {
+		t.Errorf("expected 3 bookings, got %v", len(bookings))
+	}
+}
+
+func TestBookCapacity(t *testing.T) {
+	store := NewStore(testDB)
+	bookableID, userID, point := makeTestBookable(t, store, 3)
+
+	start := time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)
+	arg := BookParams{
+		BookableID: bookableID,
+		BookedBy:   userID,
+		StartTime:  start,
+		EndTime:    start.Add(time.Hour),
+		Capacity:   2,
+	}
+	if _, err := store.Book(context.Background(), arg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Book(context.Background(), arg); !errors.Is(err, ErrBookingUnavailable) {
+		t.Errorf("expected %v booking capacity 4 of 3, got %v", ErrBookingUnavailable, err)
+	}
+
+	near := func(capacity int64) bool {
+		rows, err := store.AvailableBookablesNear(context.Background(), *point, 100, start, start.Add(time.Hour), capacity, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range rows {
+			if row.ID == bookableID {
+				return true
+			}
+		}
+		return false
+	}
+	if !near(1) {
+		t.Errorf("bookable %v with capacity 1 free was not found nearby", bookableID)
+	}
+	if near(2) {
+		t.Errorf("bookable %v with capacity 1 free was found for capacity 2", bookableID)
+	}
+}
+
+func TestBookPeakCapacity(t *testing.T) {
+	store := NewStore(testDB)
+	bookableID, userID, point := makeTestBookable(t, store, 2)
+
+	start := time.Date(2030, 1, 3, 10, 0, 0, 0, time.UTC)
+	book := func(from time.Time, capacity int64) error {
+		_, err := store.Book(context.Background(), BookParams{
+			BookableID: bookableID,
+			BookedBy:   userID,
+			StartTime:  from,
+			EndTime:    from.Add(time.Hour),
+			Capacity:   capacity,
+		})
+		return err
+	}
+
+	// Two bookings which do not overlap each other, with the clear time between them
+	if err := book(start, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := book(start.Add(75*time.Minute), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// A window overlapping both has only one in use at once
+	from := start.Add(30 * time.Minute)
+	rows, err := store.AvailableBookablesNear(context.Background(), *point, 100, from, from.Add(time.Hour), 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, row := range rows {
+		if row.ID == bookableID {
+			found = true
+			if row.FreeCapacity != 1 {
+				t.Errorf("bookable %v had free capacity %v, expected 1", bookableID, row.FreeCapacity)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("bookable %v with capacity 1 free was not found nearby", bookableID)
+	}
+
+	if err := book(from, 2); !errors.Is(err, ErrBookingUnavailable) {
+		t.Errorf("expected %v booking capacity 2 alongside 1 of 2, got %v", ErrBookingUnavailable, err)
+	}
+	if err := book(from, 1); err != nil {
+		t.Errorf("booking capacity 1 between non-overlapping bookings: %v", err)
+	}
+}
+
+// Create a bookable of the given capacity at a new location, with a one hour
+// default booking length, a 30 minute alternative and a 15 minute clear time,
+// and a user to book it
+func makeTestBookable(t *testing.T, store *Store, capacity int64) (bookableID int64, userID int64, point *geo.Point) {
+	arg := makeTestLocationParams(t)
+	location, err := store.CreateLocation(context.Background(), arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bookableID, err = store.CreateBookable(context.Background(), CreateBookableParams{
+		LocationID:                  location.ID,
+		Capacity:                    capacity,
+		Description:                 sql.NullString{String: "Test Bookable", Valid: true},
+		DefaultBookingLengthSeconds: sql.NullFloat64{Float64: time.Hour.Seconds(), Valid: true},
+		ClearTimeSeconds:            sql.NullFloat64{Float64: (15 * time.Minute).Seconds(), Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = store.CreateBookingLength(context.Background(), CreateBookingLengthParams{
+		BookableID:           sql.NullInt64{Int64: bookableID, Valid: true},
+		BookingLengthSeconds: (30 * time.Minute).Seconds(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return bookableID, arg.UserID.Int64, arg.Geo.Geometry.(*geo.Point)
+}
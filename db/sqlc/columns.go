@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+// Column types of the geometry columns, as constrained by the migrations
+var (
+	LocationGeoColumn = geo.ColumnType{GeoType: geo.PointType, Dimensions: geo.XY, SRID: 4326}
+	GISDataGeoColumn  = geo.ColumnType{GeoType: geo.UNKNOWN, Dimensions: geo.XY, SRID: 4326}
+)
+
+// Create a location, checking the geometry matches the location geo column
+// before it is sent to the database
+func (store *Store) CreateLocation(ctx context.Context, arg CreateLocationParams) (Location, error) {
+	if err := LocationGeoColumn.Check(arg.Geo); err != nil {
+		return Location{}, err
+	}
+	return store.Queries.CreateLocation(ctx, arg)
+}
+
+// Create a gisdata row, checking the geometry matches the gisdata geo column
+// before it is sent to the database
+func (store *Store) CreateGISData(ctx context.Context, argGeo geo.GISGeometry) (Gisdatum, error) {
+	if err := GISDataGeoColumn.Check(argGeo); err != nil {
+		return Gisdatum{}, err
+	}
+	return store.Queries.CreateGISData(ctx, argGeo)
+}
+
+// Update all columns of a location, checking the geometry matches the location
+// geo column before it is sent to the database
+func (store *Store) UpdateLocation(ctx context.Context, arg UpdateLocationParams) (Location, error) {
+	if err := LocationGeoColumn.Check(arg.Geo); err != nil {
+		return Location{}, err
+	}
+	return store.Queries.UpdateLocation(ctx, arg)
+}
@@ -0,0 +1,267 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/lib/pq"
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+// Default number of rows sent in each COPY batch
+const DefaultCopyBatchSize = 10000
+
+// Options controlling a bulk load through COPY
+type CopyOptions struct {
+	// Rows sent in each COPY statement. Each batch is committed in its own
+	// transaction, so a failed batch does not roll back earlier batches.
+	// Defaults to DefaultCopyBatchSize.
+	BatchSize int
+
+	// Called after each batch is committed with the total number of rows loaded
+	Progress func(loaded int64)
+}
+
+// Result of a bulk load through COPY
+type CopyResult struct {
+	// Number of rows committed
+	Loaded int64
+
+	// Rows rejected before being sent, such as those with geometry that could
+	// not be encoded. Rejected rows do not stop the load.
+	Rejected []*CopyRowError
+}
+
+// CopyRowError reports the input row responsible for a bulk load error. Row is
+// the zero based index of the row in the input slice or channel. It is always
+// used as a *CopyRowError, both in CopyResult.Rejected and as a returned error.
+type CopyRowError struct {
+	Row int
+	Err error
+}
+
+func (e *CopyRowError) Error() string {
+	return fmt.Sprintf("copy row %v: %v", e.Row, e.Err)
+}
+
+func (e *CopyRowError) Unwrap() error {
+	return e.Err
+}
+
+// A row of COPY values, with the index of the input row it was encoded from
+type copyRow struct {
+	index  int
+	values []interface{}
+}
+
+// Load locations in bulk with COPY, streaming rows to the database in batches
+// rather than inserting one row per round trip. Rows are committed batch by
+// batch - if a batch fails, the result holds the rows loaded by earlier
+// batches, and the error is a *CopyRowError where the failing row is known.
+func (store *Store) CopyLocations(ctx context.Context, arg []CreateLocationParams, opts CopyOptions) (CopyResult, error) {
+	i := 0
+	next := func() (interface{}, bool) {
+		if i == len(arg) {
+			return nil, false
+		}
+		i++
+		return arg[i-1], true
+	}
+	return store.copyIn(ctx, "location", locationCopyColumns, next, encodeLocationCopy, opts)
+}
+
+// Load locations in bulk with COPY from a channel, until the channel is closed
+// or the context is done. See CopyLocations. When the context is done, rows of
+// the unfinished batch are not sent, and the context error is returned with the
+// rows committed by earlier batches.
+func (store *Store) CopyLocationsFromChannel(ctx context.Context, arg <-chan CreateLocationParams, opts CopyOptions) (CopyResult, error) {
+	next := func() (interface{}, bool) {
+		select {
+		case p, ok := <-arg:
+			return p, ok
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+	return store.copyIn(ctx, "location", locationCopyColumns, next, encodeLocationCopy, opts)
+}
+
+// Columns of location populated by COPY, in the order of encodeLocationCopy
+var locationCopyColumns = []string{
+	"organisation_id", "user_id", "full_name", "line1", "line2", "city", "county", "country_code", "geo",
+}
+
+// Encode CreateLocationParams as COPY values
+func encodeLocationCopy(row interface{}) ([]interface{}, error) {
+	p := row.(CreateLocationParams)
+	values := make([]interface{}, 0, len(locationCopyColumns))
+	for _, v := range []driver.Valuer{
+		p.OrganisationID, p.UserID, p.FullName, p.Line1, p.Line2, p.City, p.County, p.CountryCode,
+	} {
+		value, err := v.Value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	g, err := copyGeometry(p.Geo, LocationGeoColumn)
+	if err != nil {
+		return nil, err
+	}
+	return append(values, g), nil
+}
+
+// Load gisdata geometries in bulk with COPY. See CopyLocations.
+func (store *Store) CopyGISData(ctx context.Context, arg []geo.GISGeometry, opts CopyOptions) (CopyResult, error) {
+	i := 0
+	next := func() (interface{}, bool) {
+		if i == len(arg) {
+			return nil, false
+		}
+		i++
+		return arg[i-1], true
+	}
+	encode := func(row interface{}) ([]interface{}, error) {
+		g, err := copyGeometry(row.(geo.GISGeometry), GISDataGeoColumn)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{g}, nil
+	}
+	return store.copyIn(ctx, "gisdata", []string{"geo"}, next, encode, opts)
+}
+
+// Read rows from next until it returns false, encode them and COPY them into the
+// table in batches. Once the context is done no further batch is sent.
+func (store *Store) copyIn(ctx context.Context, table string, columns []string,
+	next func() (interface{}, bool), encode func(interface{}) ([]interface{}, error), opts CopyOptions) (CopyResult, error) {
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultCopyBatchSize
+	}
+
+	var result CopyResult
+	batch := make([]copyRow, 0, batchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := store.copyBatch(ctx, table, columns, batch); err != nil {
+			return err
+		}
+		result.Loaded += int64(len(batch))
+		batch = batch[:0]
+		if opts.Progress != nil {
+			opts.Progress(result.Loaded)
+		}
+		return nil
+	}
+
+	for index := 0; ; index++ {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		row, ok := next()
+		if !ok {
+			break
+		}
+		values, err := encode(row)
+		if err != nil {
+			result.Rejected = append(result.Rejected, &CopyRowError{Row: index, Err: err})
+			continue
+		}
+		batch = append(batch, copyRow{index: index, values: values})
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	return result, flush()
+}
+
+// COPY a batch of rows into the table in a single transaction
+func (store *Store) copyBatch(ctx context.Context, table string, columns []string, batch []copyRow) error {
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = copyRows(ctx, tx, table, columns, batch)
+	if err != nil {
+		// failed copy - rollback transaction
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("copy error: %w, rollback error: %v", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// Send the rows of a batch to the COPY statement
+func copyRows(ctx context.Context, tx *sql.Tx, table string, columns []string, batch []copyRow) error {
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(table, columns...))
+	if err != nil {
+		return err
+	}
+
+	for _, row := range batch {
+		if _, err = stmt.ExecContext(ctx, row.values...); err != nil {
+			stmt.Close()
+			return copyError(err, batch)
+		}
+	}
+
+	// Flush the buffered rows - errors from the server are reported here
+	if _, err = stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return copyError(err, batch)
+	}
+	return stmt.Close()
+}
+
+// Matches the line number of the failing row in the context of a COPY error
+var copyLinePattern = regexp.MustCompile(`COPY [^,]+, line (\d+)`)
+
+// Map a COPY error to the input row responsible, where the server reports it
+func copyError(err error, batch []copyRow) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	m := copyLinePattern.FindStringSubmatch(pqErr.Where)
+	if m == nil {
+		return err
+	}
+	line, convErr := strconv.Atoi(m[1])
+	if convErr != nil || line < 1 || line > len(batch) {
+		return err
+	}
+	return &CopyRowError{Row: batch[line-1].index, Err: err}
+}
+
+// Encode a GISGeometry as hex EWKB text for COPY, checking it matches the
+// column. The driver would send the []byte from GISGeometry.Value as bytea,
+// which geometry input does not accept. As with inserts, a GISGeometry with no
+// geometry is rejected, as the geometry columns are NOT NULL.
+func copyGeometry(g geo.GISGeometry, column geo.ColumnType) (interface{}, error) {
+	if err := column.Check(g); err != nil {
+		return nil, err
+	}
+	ewkb, err := g.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return hex.EncodeToString(ewkb), nil
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestCopyLocations(t *testing.T) {
+	requirePostGIS(t)
+	store := NewStore(testDB)
+
+	var arg []CreateLocationParams
+	for i := 0; i < 5; i++ {
+		point, err := geo.NewPoint([]float64{float64(i), float64(i)}, geo.XY)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g := geo.NewGISGeometry(point)
+		g.SetSRID(4326)
+		arg = append(arg, CreateLocationParams{
+			FullName: sql.NullString{String: "copy", Valid: true},
+			Geo:      g,
+		})
+	}
+
+	var progress []int64
+	result, err := store.CopyLocations(context.Background(), arg, CopyOptions{
+		BatchSize: 2,
+		Progress:  func(loaded int64) { progress = append(progress, loaded) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Loaded != 5 || len(result.Rejected) != 0 {
+		t.Errorf("copy result %+v, expected 5 loaded", result)
+	}
+	if !cmp.Equal(progress, []int64{2, 4, 5}) {
+		t.Errorf("copy progress %v, expected [2 4 5]", progress)
+	}
+}
+
+func TestCopyGeometry(t *testing.T) {
+	point, err := geo.NewPoint([]float64{1, 2}, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := geo.NewGISGeometry(point)
+	g.SetSRID(4326)
+
+	value, err := copyGeometry(g, LocationGeoColumn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "0101000020E6100000000000000000F03F0000000000000040" && value != "0101000020e6100000000000000000f03f0000000000000040" {
+		t.Errorf("copy geometry %v was not hex ewkb", value)
+	}
+
+	// The geometry columns are NOT NULL, as for CreateLocation
+	_, err = copyGeometry(geo.GISGeometry{}, LocationGeoColumn)
+	if err == nil {
+		t.Error("copy of empty GISGeometry did not return an error")
+	}
+}
+
+func TestCopyCancelled(t *testing.T) {
+	// Without a database, sending the unfinished batch would fail
+	store := &Store{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rows := 0
+	next := func() (interface{}, bool) {
+		if rows == 2 {
+			cancel()
+			return nil, false
+		}
+		rows++
+		return rows, true
+	}
+	encode := func(row interface{}) ([]interface{}, error) {
+		if row == 2 {
+			return nil, errors.New("bad row")
+		}
+		return []interface{}{row}, nil
+	}
+	result, err := store.copyIn(ctx, "gisdata", []string{"geo"}, next, encode, CopyOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled copy returned %v, expected %v", err, context.Canceled)
+	}
+	if result.Loaded != 0 || len(result.Rejected) != 1 || result.Rejected[0].Row != 1 {
+		t.Errorf("cancelled copy result %+v, expected row 1 rejected", result)
+	}
+}
+
+func TestCopyError(t *testing.T) {
+	batch := []copyRow{{index: 3}, {index: 5}, {index: 8}}
+
+	pqErr := &pq.Error{Message: "parse error - invalid geometry", Where: "COPY location, line 2, column geo: \"zz\""}
+	err := copyError(pqErr, batch)
+	var rowErr *CopyRowError
+	if !errors.As(err, &rowErr) {
+		t.Fatalf("copy error %v was not a CopyRowError", err)
+	}
+	if rowErr.Row != 5 {
+		t.Errorf("copy error row %v, expected 5", rowErr.Row)
+	}
+	if !errors.Is(err, pqErr) {
+		t.Errorf("copy error %v does not wrap %v", err, pqErr)
+	}
+
+	other := errors.New("connection reset")
+	if err := copyError(other, batch); err != other {
+		t.Errorf("copy error %v, expected %v unchanged", err, other)
+	}
+}
@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/stephenirven/go-postgis/geo"
 )
@@ -78,6 +79,190 @@ func (q *Queries) GetGISData(ctx context.Context, id int64) (geo.GISGeometry, er
 	return geo, err
 }
 
+const getGISDataExtent = `-- name: GetGISDataExtent :one
+SELECT ST_Extent(geo::geometry)::box2d AS extent
+FROM gisdata
+`
+
+func (q *Queries) GetGISDataExtent(ctx context.Context) (geo.NullBox2D, error) {
+	row := q.db.QueryRowContext(ctx, getGISDataExtent)
+	var extent geo.NullBox2D
+	err := row.Scan(&extent)
+	return extent, err
+}
+
+const listGISDataContaining = `-- name: ListGISDataContaining :many
+SELECT id, geo::geometry
+FROM gisdata
+WHERE ST_Contains(geo, $3::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2
+`
+
+type ListGISDataContainingParams struct {
+	Limit  int64           `json:"limit"`
+	Offset int64           `json:"offset"`
+	Geo    geo.GISGeometry `json:"geo"`
+}
+
+type ListGISDataContainingRow struct {
+	ID  int64           `json:"id"`
+	Geo geo.GISGeometry `json:"geo"`
+}
+
+func (q *Queries) ListGISDataContaining(ctx context.Context, arg ListGISDataContainingParams) ([]ListGISDataContainingRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGISDataContaining, arg.Limit, arg.Offset, arg.Geo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGISDataContainingRow
+	for rows.Next() {
+		var i ListGISDataContainingRow
+		if err := rows.Scan(&i.ID, &i.Geo); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGISDataInBox = `-- name: ListGISDataInBox :many
+SELECT id, geo::geometry
+FROM gisdata
+WHERE geo && $3::geometry
+ORDER BY id
+LIMIT $1
+OFFSET $2
+`
+
+type ListGISDataInBoxParams struct {
+	Limit  int64           `json:"limit"`
+	Offset int64           `json:"offset"`
+	Geo    geo.GISGeometry `json:"geo"`
+}
+
+type ListGISDataInBoxRow struct {
+	ID  int64           `json:"id"`
+	Geo geo.GISGeometry `json:"geo"`
+}
+
+func (q *Queries) ListGISDataInBox(ctx context.Context, arg ListGISDataInBoxParams) ([]ListGISDataInBoxRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGISDataInBox, arg.Limit, arg.Offset, arg.Geo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGISDataInBoxRow
+	for rows.Next() {
+		var i ListGISDataInBoxRow
+		if err := rows.Scan(&i.ID, &i.Geo); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGISDataIntersecting = `-- name: ListGISDataIntersecting :many
+SELECT id, geo::geometry
+FROM gisdata
+WHERE ST_Intersects(geo, $3::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2
+`
+
+type ListGISDataIntersectingParams struct {
+	Limit  int64           `json:"limit"`
+	Offset int64           `json:"offset"`
+	Geo    geo.GISGeometry `json:"geo"`
+}
+
+type ListGISDataIntersectingRow struct {
+	ID  int64           `json:"id"`
+	Geo geo.GISGeometry `json:"geo"`
+}
+
+func (q *Queries) ListGISDataIntersecting(ctx context.Context, arg ListGISDataIntersectingParams) ([]ListGISDataIntersectingRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGISDataIntersecting, arg.Limit, arg.Offset, arg.Geo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGISDataIntersectingRow
+	for rows.Next() {
+		var i ListGISDataIntersectingRow
+		if err := rows.Scan(&i.ID, &i.Geo); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGISDataWithin = `-- name: ListGISDataWithin :many
+SELECT id, geo::geometry
+FROM gisdata
+WHERE ST_Within(geo, $3::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2
+`
+
+type ListGISDataWithinParams struct {
+	Limit  int64           `json:"limit"`
+	Offset int64           `json:"offset"`
+	Geo    geo.GISGeometry `json:"geo"`
+}
+
+type ListGISDataWithinRow struct {
+	ID  int64           `json:"id"`
+	Geo geo.GISGeometry `json:"geo"`
+}
+
+func (q *Queries) ListGISDataWithin(ctx context.Context, arg ListGISDataWithinParams) ([]ListGISDataWithinRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGISDataWithin, arg.Limit, arg.Offset, arg.Geo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGISDataWithinRow
+	for rows.Next() {
+		var i ListGISDataWithinRow
+		if err := rows.Scan(&i.ID, &i.Geo); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listGISDataWithinDistance = `-- name: ListGISDataWithinDistance :many
 SELECT geo::geometry 
 FROM gisdata
@@ -125,3 +310,57 @@ func (q *Queries) ListGISDataWithinDistance(ctx context.Context, arg ListGISData
 	}
 	return items, nil
 }
+
+const listNearestGISData = `-- name: ListNearestGISData :many
+SELECT id, geo::geometry, (geo <-> $1::geometry)::FLOAT8 AS distance
+FROM gisdata
+WHERE
+  geo IS NOT NULL
+  AND (
+    $2::FLOAT8 IS NULL
+    OR (geo <-> $1::geometry) > $2::FLOAT8
+    OR ((geo <-> $1::geometry) = $2::FLOAT8 AND id > $3::BIGINT))
+ORDER BY geo <-> $1::geometry, id
+LIMIT $4
+`
+
+type ListNearestGISDataParams struct {
+	Point         geo.GISGeometry `json:"point"`
+	AfterDistance sql.NullFloat64 `json:"after_distance"`
+	AfterID       sql.NullInt64   `json:"after_id"`
+	Count         int64           `json:"count"`
+}
+
+type ListNearestGISDataRow struct {
+	ID       int64           `json:"id"`
+	Geo      geo.GISGeometry `json:"geo"`
+	Distance float64         `json:"distance"`
+}
+
+func (q *Queries) ListNearestGISData(ctx context.Context, arg ListNearestGISDataParams) ([]ListNearestGISDataRow, error) {
+	rows, err := q.db.QueryContext(ctx, listNearestGISData,
+		arg.Point,
+		arg.AfterDistance,
+		arg.AfterID,
+		arg.Count,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListNearestGISDataRow
+	for rows.Next() {
+		var i ListNearestGISDataRow
+		if err := rows.Scan(&i.ID, &i.Geo, &i.Distance); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
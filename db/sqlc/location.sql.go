@@ -15,20 +15,37 @@ import (
 
 const createLocation = `-- name: CreateLocation :one
 INSERT INTO location (
-  full_name, geo
+  organisation_id, user_id, full_name, line1, line2, city, county, country_code, geo
 ) VALUES (
-  $1, $2::geometry
+  $1, $2, $3, $4, $5, $6, $7, $8, $9::geometry
 )
 RETURNING id, organisation_id, user_id, full_name, line1, line2, city, county, country_code, geo, created_at
 `
 
 type CreateLocationParams struct {
-	FullName sql.NullString  `json:"full_name"`
-	Geo      geo.GISGeometry `json:"geo"`
+	OrganisationID sql.NullInt64   `json:"organisation_id"`
+	UserID         sql.NullInt64   `json:"user_id"`
+	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
+	Line2          sql.NullString  `json:"line2"`
+	City           sql.NullString  `json:"city"`
+	County         sql.NullString  `json:"county"`
+	CountryCode    sql.NullString  `json:"country_code"`
+	Geo            geo.GISGeometry `json:"geo"`
 }
 
 func (q *Queries) CreateLocation(ctx context.Context, arg CreateLocationParams) (Location, error) {
-	row := q.db.QueryRowContext(ctx, createLocation, arg.FullName, arg.Geo)
+	row := q.db.QueryRowContext(ctx, createLocation,
+		arg.OrganisationID,
+		arg.UserID,
+		arg.FullName,
+		arg.Line1,
+		arg.Line2,
+		arg.City,
+		arg.County,
+		arg.CountryCode,
+		arg.Geo,
+	)
 	var i Location
 	err := row.Scan(
 		&i.ID,
@@ -59,7 +76,7 @@ func (q *Queries) DeleteLocation(ctx context.Context, id int64) error {
 const getLocation = `-- name: GetLocation :one
 SELECT 
 		id, organisation_id, user_id, full_name,
-		line1
+		line1,
 		line2,
 		city,
 		county,
@@ -75,6 +92,7 @@ type GetLocationRow struct {
 	OrganisationID sql.NullInt64   `json:"organisation_id"`
 	UserID         sql.NullInt64   `json:"user_id"`
 	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
 	Line2          sql.NullString  `json:"line2"`
 	City           sql.NullString  `json:"city"`
 	County         sql.NullString  `json:"county"`
@@ -91,6 +109,7 @@ func (q *Queries) GetLocation(ctx context.Context, id int64) (GetLocationRow, er
 		&i.OrganisationID,
 		&i.UserID,
 		&i.FullName,
+		&i.Line1,
 		&i.Line2,
 		&i.City,
 		&i.County,
@@ -101,10 +120,23 @@ func (q *Queries) GetLocation(ctx context.Context, id int64) (GetLocationRow, er
 	return i, err
 }
 
+const getLocationBox = `-- name: GetLocationBox :one
+SELECT Box2D(geo::geometry)::box2d AS box
+FROM location
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetLocationBox(ctx context.Context, id int64) (geo.NullBox2D, error) {
+	row := q.db.QueryRowContext(ctx, getLocationBox, id)
+	var box geo.NullBox2D
+	err := row.Scan(&box)
+	return box, err
+}
+
 const getLocationForUpdate = `-- name: GetLocationForUpdate :one
 SELECT 
 		id, organisation_id, user_id, full_name,
-		line1
+		line1,
 		line2,
 		city,
 		county,
@@ -121,6 +153,7 @@ type GetLocationForUpdateRow struct {
 	OrganisationID sql.NullInt64   `json:"organisation_id"`
 	UserID         sql.NullInt64   `json:"user_id"`
 	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
 	Line2          sql.NullString  `json:"line2"`
 	City           sql.NullString  `json:"city"`
 	County         sql.NullString  `json:"county"`
@@ -137,6 +170,7 @@ func (q *Queries) GetLocationForUpdate(ctx context.Context, id int64) (GetLocati
 		&i.OrganisationID,
 		&i.UserID,
 		&i.FullName,
+		&i.Line1,
 		&i.Line2,
 		&i.City,
 		&i.County,
@@ -149,7 +183,7 @@ func (q *Queries) GetLocationForUpdate(ctx context.Context, id int64) (GetLocati
 
 const getLocations = `-- name: GetLocations :many
 SELECT id, organisation_id, user_id, full_name,
-		line1
+		line1,
 		line2,
 		city,
 		county,
@@ -164,6 +198,7 @@ type GetLocationsRow struct {
 	OrganisationID sql.NullInt64   `json:"organisation_id"`
 	UserID         sql.NullInt64   `json:"user_id"`
 	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
 	Line2          sql.NullString  `json:"line2"`
 	City           sql.NullString  `json:"city"`
 	County         sql.NullString  `json:"county"`
@@ -186,6 +221,347 @@ func (q *Queries) GetLocations(ctx context.Context) ([]GetLocationsRow, error) {
 			&i.OrganisationID,
 			&i.UserID,
 			&i.FullName,
+			&i.Line1,
+			&i.Line2,
+			&i.City,
+			&i.County,
+			&i.CountryCode,
+			&i.Geo,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrganisationConvexHull = `-- name: GetOrganisationConvexHull :one
+SELECT COALESCE(ST_ConvexHull(ST_Collect(geo)), 'SRID=4326;GEOMETRYCOLLECTION EMPTY'::geometry)::geometry AS hull
+FROM location
+WHERE organisation_id = $1
+`
+
+func (q *Queries) GetOrganisationConvexHull(ctx context.Context, organisationID sql.NullInt64) (geo.GISGeometry, error) {
+	row := q.db.QueryRowContext(ctx, getOrganisationConvexHull, organisationID)
+	var hull geo.GISGeometry
+	err := row.Scan(&hull)
+	return hull, err
+}
+
+const getOrganisationCoverage = `-- name: GetOrganisationCoverage :one
+SELECT COALESCE(ST_Union(geo), 'SRID=4326;GEOMETRYCOLLECTION EMPTY'::geometry)::geometry AS coverage
+FROM location
+WHERE organisation_id = $1
+`
+
+func (q *Queries) GetOrganisationCoverage(ctx context.Context, organisationID sql.NullInt64) (geo.GISGeometry, error) {
+	row := q.db.QueryRowContext(ctx, getOrganisationCoverage, organisationID)
+	var coverage geo.GISGeometry
+	err := row.Scan(&coverage)
+	return coverage, err
+}
+
+const getOrganisationLocations3DExtent = `-- name: GetOrganisationLocations3DExtent :one
+SELECT ST_3DExtent(geo::geometry)::box3d AS extent
+FROM location
+WHERE organisation_id = $1
+`
+
+func (q *Queries) GetOrganisationLocations3DExtent(ctx context.Context, organisationID sql.NullInt64) (geo.NullBox3D, error) {
+	row := q.db.QueryRowContext(ctx, getOrganisationLocations3DExtent, organisationID)
+	var extent geo.NullBox3D
+	err := row.Scan(&extent)
+	return extent, err
+}
+
+const getOrganisationLocationsExtent = `-- name: GetOrganisationLocationsExtent :one
+SELECT ST_Extent(geo::geometry)::box2d AS extent
+FROM location
+WHERE organisation_id = $1
+`
+
+func (q *Queries) GetOrganisationLocationsExtent(ctx context.Context, organisationID sql.NullInt64) (geo.NullBox2D, error) {
+	row := q.db.QueryRowContext(ctx, getOrganisationLocationsExtent, organisationID)
+	var extent geo.NullBox2D
+	err := row.Scan(&extent)
+	return extent, err
+}
+
+const listLocationsContaining = `-- name: ListLocationsContaining :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at
+FROM location
+WHERE ST_Contains(geo, $3::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2
+`
+
+type ListLocationsContainingParams struct {
+	Limit  int64           `json:"limit"`
+	Offset int64           `json:"offset"`
+	Geo    geo.GISGeometry `json:"geo"`
+}
+
+type ListLocationsContainingRow struct {
+	ID             int64           `json:"id"`
+	OrganisationID sql.NullInt64   `json:"organisation_id"`
+	UserID         sql.NullInt64   `json:"user_id"`
+	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
+	Line2          sql.NullString  `json:"line2"`
+	City           sql.NullString  `json:"city"`
+	County         sql.NullString  `json:"county"`
+	CountryCode    sql.NullString  `json:"country_code"`
+	Geo            geo.GISGeometry `json:"geo"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func (q *Queries) ListLocationsContaining(ctx context.Context, arg ListLocationsContainingParams) ([]ListLocationsContainingRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLocationsContaining, arg.Limit, arg.Offset, arg.Geo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLocationsContainingRow
+	for rows.Next() {
+		var i ListLocationsContainingRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganisationID,
+			&i.UserID,
+			&i.FullName,
+			&i.Line1,
+			&i.Line2,
+			&i.City,
+			&i.County,
+			&i.CountryCode,
+			&i.Geo,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLocationsInBox = `-- name: ListLocationsInBox :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at
+FROM location
+WHERE geo && $3::geometry
+ORDER BY id
+LIMIT $1
+OFFSET $2
+`
+
+type ListLocationsInBoxParams struct {
+	Limit  int64           `json:"limit"`
+	Offset int64           `json:"offset"`
+	Geo    geo.GISGeometry `json:"geo"`
+}
+
+type ListLocationsInBoxRow struct {
+	ID             int64           `json:"id"`
+	OrganisationID sql.NullInt64   `json:"organisation_id"`
+	UserID         sql.NullInt64   `json:"user_id"`
+	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
+	Line2          sql.NullString  `json:"line2"`
+	City           sql.NullString  `json:"city"`
+	County         sql.NullString  `json:"county"`
+	CountryCode    sql.NullString  `json:"country_code"`
+	Geo            geo.GISGeometry `json:"geo"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func (q *Queries) ListLocationsInBox(ctx context.Context, arg ListLocationsInBoxParams) ([]ListLocationsInBoxRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLocationsInBox, arg.Limit, arg.Offset, arg.Geo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLocationsInBoxRow
+	for rows.Next() {
+		var i ListLocationsInBoxRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganisationID,
+			&i.UserID,
+			&i.FullName,
+			&i.Line1,
+			&i.Line2,
+			&i.City,
+			&i.County,
+			&i.CountryCode,
+			&i.Geo,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLocationsIntersecting = `-- name: ListLocationsIntersecting :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at
+FROM location
+WHERE ST_Intersects(geo, $3::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2
+`
+
+type ListLocationsIntersectingParams struct {
+	Limit  int64           `json:"limit"`
+	Offset int64           `json:"offset"`
+	Geo    geo.GISGeometry `json:"geo"`
+}
+
+type ListLocationsIntersectingRow struct {
+	ID             int64           `json:"id"`
+	OrganisationID sql.NullInt64   `json:"organisation_id"`
+	UserID         sql.NullInt64   `json:"user_id"`
+	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
+	Line2          sql.NullString  `json:"line2"`
+	City           sql.NullString  `json:"city"`
+	County         sql.NullString  `json:"county"`
+	CountryCode    sql.NullString  `json:"country_code"`
+	Geo            geo.GISGeometry `json:"geo"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func (q *Queries) ListLocationsIntersecting(ctx context.Context, arg ListLocationsIntersectingParams) ([]ListLocationsIntersectingRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLocationsIntersecting, arg.Limit, arg.Offset, arg.Geo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLocationsIntersectingRow
+	for rows.Next() {
+		var i ListLocationsIntersectingRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganisationID,
+			&i.UserID,
+			&i.FullName,
+			&i.Line1,
+			&i.Line2,
+			&i.City,
+			&i.County,
+			&i.CountryCode,
+			&i.Geo,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLocationsWithin = `-- name: ListLocationsWithin :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at
+FROM location
+WHERE ST_Within(geo, $3::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2
+`
+
+type ListLocationsWithinParams struct {
+	Limit  int64           `json:"limit"`
+	Offset int64           `json:"offset"`
+	Geo    geo.GISGeometry `json:"geo"`
+}
+
+type ListLocationsWithinRow struct {
+	ID             int64           `json:"id"`
+	OrganisationID sql.NullInt64   `json:"organisation_id"`
+	UserID         sql.NullInt64   `json:"user_id"`
+	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
+	Line2          sql.NullString  `json:"line2"`
+	City           sql.NullString  `json:"city"`
+	County         sql.NullString  `json:"county"`
+	CountryCode    sql.NullString  `json:"country_code"`
+	Geo            geo.GISGeometry `json:"geo"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func (q *Queries) ListLocationsWithin(ctx context.Context, arg ListLocationsWithinParams) ([]ListLocationsWithinRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLocationsWithin, arg.Limit, arg.Offset, arg.Geo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLocationsWithinRow
+	for rows.Next() {
+		var i ListLocationsWithinRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganisationID,
+			&i.UserID,
+			&i.FullName,
+			&i.Line1,
 			&i.Line2,
 			&i.City,
 			&i.County,
@@ -209,7 +585,7 @@ func (q *Queries) GetLocations(ctx context.Context) ([]GetLocationsRow, error) {
 const listLocationsWithinDistance = `-- name: ListLocationsWithinDistance :many
 SELECT 
 		id, organisation_id, user_id, full_name,
-		line1
+		line1,
 		line2,
 		city,
 		county,
@@ -239,6 +615,7 @@ type ListLocationsWithinDistanceRow struct {
 	OrganisationID sql.NullInt64   `json:"organisation_id"`
 	UserID         sql.NullInt64   `json:"user_id"`
 	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
 	Line2          sql.NullString  `json:"line2"`
 	City           sql.NullString  `json:"city"`
 	County         sql.NullString  `json:"county"`
@@ -266,6 +643,7 @@ func (q *Queries) ListLocationsWithinDistance(ctx context.Context, arg ListLocat
 			&i.OrganisationID,
 			&i.UserID,
 			&i.FullName,
+			&i.Line1,
 			&i.Line2,
 			&i.City,
 			&i.County,
@@ -285,3 +663,313 @@ func (q *Queries) ListLocationsWithinDistance(ctx context.Context, arg ListLocat
 	}
 	return items, nil
 }
+
+const listNearestLocations = `-- name: ListNearestLocations :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at,
+		(geo <-> $1::geometry)::FLOAT8 AS distance
+FROM location
+WHERE
+  geo IS NOT NULL
+  AND ($2::BIGINT IS NULL OR organisation_id = $2::BIGINT)
+  AND (
+    $3::FLOAT8 IS NULL
+    OR (geo <-> $1::geometry) > $3::FLOAT8
+    OR ((geo <-> $1::geometry) = $3::FLOAT8 AND id > $4::BIGINT))
+ORDER BY geo <-> $1::geometry, id
+LIMIT $5
+`
+
+type ListNearestLocationsParams struct {
+	Point          geo.GISGeometry `json:"point"`
+	OrganisationID sql.NullInt64   `json:"organisation_id"`
+	AfterDistance  sql.NullFloat64 `json:"after_distance"`
+	AfterID        sql.NullInt64   `json:"after_id"`
+	Count          int64           `json:"count"`
+}
+
+type ListNearestLocationsRow struct {
+	ID             int64           `json:"id"`
+	OrganisationID sql.NullInt64   `json:"organisation_id"`
+	UserID         sql.NullInt64   `json:"user_id"`
+	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
+	Line2          sql.NullString  `json:"line2"`
+	City           sql.NullString  `json:"city"`
+	County         sql.NullString  `json:"county"`
+	CountryCode    sql.NullString  `json:"country_code"`
+	Geo            geo.GISGeometry `json:"geo"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Distance       float64         `json:"distance"`
+}
+
+func (q *Queries) ListNearestLocations(ctx context.Context, arg ListNearestLocationsParams) ([]ListNearestLocationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listNearestLocations,
+		arg.Point,
+		arg.OrganisationID,
+		arg.AfterDistance,
+		arg.AfterID,
+		arg.Count,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListNearestLocationsRow
+	for rows.Next() {
+		var i ListNearestLocationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganisationID,
+			&i.UserID,
+			&i.FullName,
+			&i.Line1,
+			&i.Line2,
+			&i.City,
+			&i.County,
+			&i.CountryCode,
+			&i.Geo,
+			&i.CreatedAt,
+			&i.Distance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrganisationLocationsWithin = `-- name: ListOrganisationLocationsWithin :many
+SELECT 
+		id, organisation_id, user_id, full_name,
+		line1,
+		line2,
+		city,
+		county,
+		country_code,
+		geo::geometry,
+		created_at
+FROM location
+WHERE organisation_id = $3 AND ST_Within(geo, $4::geometry)
+ORDER BY id
+LIMIT $1
+OFFSET $2
+`
+
+type ListOrganisationLocationsWithinParams struct {
+	Limit          int64           `json:"limit"`
+	Offset         int64           `json:"offset"`
+	OrganisationID sql.NullInt64   `json:"organisation_id"`
+	Geo            geo.GISGeometry `json:"geo"`
+}
+
+type ListOrganisationLocationsWithinRow struct {
+	ID             int64           `json:"id"`
+	OrganisationID sql.NullInt64   `json:"organisation_id"`
+	UserID         sql.NullInt64   `json:"user_id"`
+	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
+	Line2          sql.NullString  `json:"line2"`
+	City           sql.NullString  `json:"city"`
+	County         sql.NullString  `json:"county"`
+	CountryCode    sql.NullString  `json:"country_code"`
+	Geo            geo.GISGeometry `json:"geo"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+func (q *Queries) ListOrganisationLocationsWithin(ctx context.Context, arg ListOrganisationLocationsWithinParams) ([]ListOrganisationLocationsWithinRow, error) {
+	rows, err := q.db.QueryContext(ctx, listOrganisationLocationsWithin,
+		arg.Limit,
+		arg.Offset,
+		arg.OrganisationID,
+		arg.Geo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrganisationLocationsWithinRow
+	for rows.Next() {
+		var i ListOrganisationLocationsWithinRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganisationID,
+			&i.UserID,
+			&i.FullName,
+			&i.Line1,
+			&i.Line2,
+			&i.City,
+			&i.County,
+			&i.CountryCode,
+			&i.Geo,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const patchLocation = `-- name: PatchLocation :one
+UPDATE location
+  set organisation_id = COALESCE($1, organisation_id),
+  user_id = COALESCE($2, user_id),
+  full_name = COALESCE($3, full_name),
+  line1 = COALESCE($4, line1),
+  line2 = COALESCE($5, line2),
+  city = COALESCE($6, city),
+  county = COALESCE($7, county),
+  country_code = COALESCE($8, country_code)
+WHERE id = $9
+RETURNING id, organisation_id, user_id, full_name, line1, line2, city, county, country_code, geo, created_at
+`
+
+type PatchLocationParams struct {
+	OrganisationID sql.NullInt64  `json:"organisation_id"`
+	UserID         sql.NullInt64  `json:"user_id"`
+	FullName       sql.NullString `json:"full_name"`
+	Line1          sql.NullString `json:"line1"`
+	Line2          sql.NullString `json:"line2"`
+	City           sql.NullString `json:"city"`
+	County         sql.NullString `json:"county"`
+	CountryCode    sql.NullString `json:"country_code"`
+	ID             int64          `json:"id"`
+}
+
+func (q *Queries) PatchLocation(ctx context.Context, arg PatchLocationParams) (Location, error) {
+	row := q.db.QueryRowContext(ctx, patchLocation,
+		arg.OrganisationID,
+		arg.UserID,
+		arg.FullName,
+		arg.Line1,
+		arg.Line2,
+		arg.City,
+		arg.County,
+		arg.CountryCode,
+		arg.ID,
+	)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.OrganisationID,
+		&i.UserID,
+		&i.FullName,
+		&i.Line1,
+		&i.Line2,
+		&i.City,
+		&i.County,
+		&i.CountryCode,
+		&i.Geo,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateLocation = `-- name: UpdateLocation :one
+UPDATE location
+  set organisation_id = $2,
+  user_id = $3,
+  full_name = $4,
+  line1 = $5,
+  line2 = $6,
+  city = $7,
+  county = $8,
+  country_code = $9,
+  geo = $10::geometry
+WHERE id = $1
+RETURNING id, organisation_id, user_id, full_name, line1, line2, city, county, country_code, geo, created_at
+`
+
+type UpdateLocationParams struct {
+	ID             int64           `json:"id"`
+	OrganisationID sql.NullInt64   `json:"organisation_id"`
+	UserID         sql.NullInt64   `json:"user_id"`
+	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
+	Line2          sql.NullString  `json:"line2"`
+	City           sql.NullString  `json:"city"`
+	County         sql.NullString  `json:"county"`
+	CountryCode    sql.NullString  `json:"country_code"`
+	Geo            geo.GISGeometry `json:"geo"`
+}
+
+func (q *Queries) UpdateLocation(ctx context.Context, arg UpdateLocationParams) (Location, error) {
+	row := q.db.QueryRowContext(ctx, updateLocation,
+		arg.ID,
+		arg.OrganisationID,
+		arg.UserID,
+		arg.FullName,
+		arg.Line1,
+		arg.Line2,
+		arg.City,
+		arg.County,
+		arg.CountryCode,
+		arg.Geo,
+	)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.OrganisationID,
+		&i.UserID,
+		&i.FullName,
+		&i.Line1,
+		&i.Line2,
+		&i.City,
+		&i.County,
+		&i.CountryCode,
+		&i.Geo,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateLocationGeo = `-- name: UpdateLocationGeo :one
+UPDATE location
+  set geo = $1::geometry
+WHERE id = $2
+RETURNING id, organisation_id, user_id, full_name, line1, line2, city, county, country_code, geo, created_at
+`
+
+type UpdateLocationGeoParams struct {
+	Geo geo.GISGeometry `json:"geo"`
+	ID  int64           `json:"id"`
+}
+
+func (q *Queries) UpdateLocationGeo(ctx context.Context, arg UpdateLocationGeoParams) (Location, error) {
+	row := q.db.QueryRowContext(ctx, updateLocationGeo, arg.Geo, arg.ID)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.OrganisationID,
+		&i.UserID,
+		&i.FullName,
+		&i.Line1,
+		&i.Line2,
+		&i.City,
+		&i.County,
+		&i.CountryCode,
+		&i.Geo,
+		&i.CreatedAt,
+	)
+	return i, err
+}
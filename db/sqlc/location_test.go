@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestCreateLocation(t *testing.T) {
+	store := NewStore(testDB)
+	arg := makeTestLocationParams(t)
+
+	location, err := store.CreateLocation(context.Background(), arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := store.GetLocation(context.Background(), location.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := CreateLocationParams{
+		OrganisationID: row.OrganisationID,
+		UserID:         row.UserID,
+		FullName:       row.FullName,
+		Line1:          row.Line1,
+		Line2:          row.Line2,
+		City:           row.City,
+		County:         row.County,
+		CountryCode:    row.CountryCode,
+		Geo:            row.Geo,
+	}
+	if !cmp.Equal(arg, got) {
+		t.Errorf("location %+v was not equal to %+v", got, arg)
+	}
+}
+
+func TestUpdateLocation(t *testing.T) {
+	store := NewStore(testDB)
+	location, err := store.CreateLocation(context.Background(), makeTestLocationParams(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update := makeTestLocationParams(t)
+	arg := UpdateLocationParams{
+		ID:             location.ID,
+		OrganisationID: update.OrganisationID,
+		UserID:         update.UserID,
+		FullName:       update.FullName,
+		Line1:          update.Line1,
+		Line2:          update.Line2,
+		City:           update.City,
+		County:         update.County,
+		CountryCode:    update.CountryCode,
+		Geo:            update.Geo,
+	}
+	_, err = store.UpdateLocation(context.Background(), arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := store.GetLocation(context.Background(), location.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := UpdateLocationParams{
+		ID:             row.ID,
+		OrganisationID: row.OrganisationID,
+		UserID:         row.UserID,
+		FullName:       row.FullName,
+		Line1:          row.Line1,
+		Line2:          row.Line2,
+		City:           row.City,
+		County:         row.County,
+		CountryCode:    row.CountryCode,
+		Geo:            row.Geo,
+	}
+	if !cmp.Equal(arg, got) {
+		t.Errorf("location %+v was not equal to %+v", got, arg)
+	}
+}
+
+func TestPatchLocation(t *testing.T) {
+	store := NewStore(testDB)
+	arg := makeTestLocationParams(t)
+	location, err := store.CreateLocation(context.Background(), arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	patched, err := store.PatchLocation(context.Background(), PatchLocationParams{
+		ID:   location.ID,
+		City: sql.NullString{String: "Patched", Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched.City.String != "Patched" {
+		t.Errorf("location city %v was not patched", patched.City)
+	}
+	if !cmp.Equal(patched.Line1, arg.Line1) || !cmp.Equal(patched.OrganisationID, arg.OrganisationID) {
+		t.Errorf("location %+v columns were changed by patching city", patched)
+	}
+}
+
+func TestMoveLocation(t *testing.T) {
+	store := NewStore(testDB)
+	arg := makeTestLocationParams(t)
+	location, err := store.CreateLocation(context.Background(), arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	to := makeTestLocationParams(t).Geo
+	result, err := store.MoveLocation(context.Background(), MoveLocationParams{ID: location.ID, Geo: to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(result.From, arg.Geo) {
+		t.Errorf("location moved from %v, expected %v", result.From, arg.Geo)
+	}
+
+	row, err := store.GetLocation(context.Background(), location.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(row.Geo, to) {
+		t.Errorf("location geometry %v was not moved to %v", row.Geo, to)
+	}
+
+	line, err := geo.NewLineString([]geo.Point{*makeTestLocationPoint(t), *makeTestLocationPoint(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.MoveLocation(context.Background(), MoveLocationParams{ID: location.ID, Geo: geo.NewGISGeometry(line)})
+	if err == nil {
+		t.Errorf("location was moved to a linestring")
+	}
+}
+
+// Create parameters for a location with every column populated
+func makeTestLocationParams(t *testing.T) CreateLocationParams {
+	organisation, err := testQueries.CreateOrganisation(context.Background(), CreateOrganisationParams{
+		CountryCode:  sql.NullInt32{Int32: 826, Valid: true},
+		MerchantName: sql.NullString{String: "Test Merchant", Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := makeTestUser(t, organisation.ID)
+
+	g := geo.NewGISGeometry(makeTestLocationPoint(t))
+	g.SetSRID(4326)
+
+	return CreateLocationParams{
+		OrganisationID: sql.NullInt64{Int64: organisation.ID, Valid: true},
+		UserID:         sql.NullInt64{Int64: user.ID, Valid: true},
+		FullName:       sql.NullString{String: "Test Location", Valid: true},
+		Line1:          sql.NullString{String: "1 High Street", Valid: true},
+		Line2:          sql.NullString{String: "Town Centre", Valid: true},
+		City:           sql.NullString{String: "Bristol", Valid: true},
+		County:         sql.NullString{String: "Avon", Valid: true},
+		CountryCode:    sql.NullString{String: "GBR", Valid: true},
+		Geo:            g,
+	}
+}
+
+// Create a random longitude/latitude point
+func makeTestLocationPoint(t *testing.T) *geo.Point {
+	point, err := geo.NewPoint([]float64{rand.Float64()*360 - 180, rand.Float64()*180 - 90}, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return point
+}
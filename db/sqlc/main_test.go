@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	_ "github.com/lib/pq"
+	"github.com/stephenirven/go-postgis/db/fakedb"
 )
 
 const (
@@ -17,6 +18,9 @@ const (
 var testQueries *Queries
 var testDB *sql.DB
 
+// Whether the tests are running against PostGIS, rather than the in-memory fake
+var testPostGIS bool
+
 func TestMain(m *testing.M) {
 	var err error
 
@@ -25,8 +29,26 @@ func TestMain(m *testing.M) {
 		log.Fatal("failed to connect to database", err)
 	}
 
+	testPostGIS = testDB.Ping() == nil
+	if !testPostGIS {
+		log.Printf("PostGIS is not available at %v, testing against %v", dbSource, fakedb.DriverName)
+		testDB.Close()
+		testDB, err = fakedb.Open("db")
+		if err != nil {
+			log.Fatal("failed to open fake database", err)
+		}
+	}
+
 	testQueries = New(testDB)
 
 	os.Exit(m.Run())
 
 }
+
+// Skip tests of queries the in-memory fake does not implement when PostGIS is not available
+func requirePostGIS(t *testing.T) {
+	t.Helper()
+	if !testPostGIS {
+		t.Skip("requires PostGIS")
+	}
+}
@@ -7,6 +7,8 @@ package db
 import (
 	"database/sql"
 	"time"
+
+	"github.com/stephenirven/go-postgis/geo"
 )
 
 type Bookable struct {
@@ -37,23 +39,28 @@ type BookingLength struct {
 	CreatedAt            time.Time     `json:"created_at"`
 }
 
+type Geogdatum struct {
+	ID   int64            `json:"id"`
+	Geog geo.GISGeography `json:"geog"`
+}
+
 type Gisdatum struct {
-	ID  int64       `json:"id"`
-	Geo interface{} `json:"geo"`
+	ID  int64           `json:"id"`
+	Geo geo.GISGeometry `json:"geo"`
 }
 
 type Location struct {
-	ID             int64          `json:"id"`
-	OrganisationID sql.NullInt64  `json:"organisation_id"`
-	UserID         sql.NullInt64  `json:"user_id"`
-	FullName       sql.NullString `json:"full_name"`
-	Line1          sql.NullString `json:"line1"`
-	Line2          sql.NullString `json:"line2"`
-	City           sql.NullString `json:"city"`
-	County         sql.NullString `json:"county"`
-	CountryCode    sql.NullString `json:"country_code"`
-	Geo            interface{}    `json:"geo"`
-	CreatedAt      time.Time      `json:"created_at"`
+	ID             int64           `json:"id"`
+	OrganisationID sql.NullInt64   `json:"organisation_id"`
+	UserID         sql.NullInt64   `json:"user_id"`
+	FullName       sql.NullString  `json:"full_name"`
+	Line1          sql.NullString  `json:"line1"`
+	Line2          sql.NullString  `json:"line2"`
+	City           sql.NullString  `json:"city"`
+	County         sql.NullString  `json:"county"`
+	CountryCode    sql.NullString  `json:"country_code"`
+	Geo            geo.GISGeometry `json:"geo"`
+	CreatedAt      time.Time       `json:"created_at"`
 }
 
 type Organisation struct {
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+// NearestCursor marks the last row of a page of nearest neighbour results, so
+// the following page can be fetched by keyset pagination. Distances are in the
+// units of the column SRID.
+type NearestCursor struct {
+	Distance float64
+	ID       int64
+}
+
+// Get the cursor for the page following this row
+func (r ListNearestLocationsRow) Cursor() NearestCursor {
+	return NearestCursor{Distance: r.Distance, ID: r.ID}
+}
+
+// Get the cursor for the page following this row
+func (r ListNearestGISDataRow) Cursor() NearestCursor {
+	return NearestCursor{Distance: r.Distance, ID: r.ID}
+}
+
+// Get the n locations nearest to the point, closest first, optionally only
+// those of an organisation. If after is not nil, the locations following it
+// are returned. The point is in the SRID of the location geo column.
+func (store *Store) NearestLocations(ctx context.Context, point geo.Point, n int64, organisationID sql.NullInt64, after *NearestCursor) ([]ListNearestLocationsRow, error) {
+	arg := ListNearestLocationsParams{
+		Point:          nearestPoint(point, LocationGeoColumn),
+		OrganisationID: organisationID,
+		Count:          n,
+	}
+	arg.AfterDistance, arg.AfterID = nearestAfter(after)
+	return store.ListNearestLocations(ctx, arg)
+}
+
+// Get the n gisdata rows nearest to the point, closest first. If after is not
+// nil, the rows following it are returned. The point is in the SRID of the
+// gisdata geo column.
+func (store *Store) NearestGISData(ctx context.Context, point geo.Point, n int64, after *NearestCursor) ([]ListNearestGISDataRow, error) {
+	arg := ListNearestGISDataParams{
+		Point: nearestPoint(point, GISDataGeoColumn),
+		Count: n,
+	}
+	arg.AfterDistance, arg.AfterID = nearestAfter(after)
+	return store.ListNearestGISData(ctx, arg)
+}
+
+// Create the query geometry for a point in the SRID of the column
+func nearestPoint(point geo.Point, column geo.ColumnType) geo.GISGeometry {
+	g := geo.NewGISGeometry(&point)
+	g.SetSRID(column.SRID)
+	return g
+}
+
+// Get the keyset parameters for the page following the cursor
+func nearestAfter(after *NearestCursor) (sql.NullFloat64, sql.NullInt64) {
+	if after == nil {
+		return sql.NullFloat64{}, sql.NullInt64{}
+	}
+	return sql.NullFloat64{Float64: after.Distance, Valid: true}, sql.NullInt64{Int64: after.ID, Valid: true}
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestNearestLocations(t *testing.T) {
+	store := NewStore(testDB)
+
+	for i := 0; i < 5; i++ {
+		point, err := geo.NewPoint([]float64{-170 + float64(i), -80}, geo.XY)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g := geo.NewGISGeometry(point)
+		g.SetSRID(4326)
+		_, err = store.CreateLocation(context.Background(), CreateLocationParams{Geo: g})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	origin, err := geo.NewPoint([]float64{-170, -80}, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := store.NearestLocations(context.Background(), *origin, 3, sql.NullInt64{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 3 {
+		t.Fatalf("expected 3 nearest locations, got %v", len(first))
+	}
+	for i := 1; i < len(first); i++ {
+		if first[i].Distance < first[i-1].Distance {
+			t.Errorf("location %v at %v is before location %v at %v", first[i-1].ID, first[i-1].Distance, first[i].ID, first[i].Distance)
+		}
+	}
+
+	cursor := first[len(first)-1].Cursor()
+	second, err := store.NearestLocations(context.Background(), *origin, 3, sql.NullInt64{}, &cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range second {
+		if row.Distance < cursor.Distance || (row.Distance == cursor.Distance && row.ID <= cursor.ID) {
+			t.Errorf("location %v at %v was repeated before cursor %+v", row.ID, row.Distance, cursor)
+		}
+	}
+}
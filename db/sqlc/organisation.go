@@ -0,0 +1,35 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+// Get the locations of an organisation within the geometry
+func (store *Store) OrganisationLocationsWithin(ctx context.Context, organisationID int64, g geo.GISGeometry, limit int64, offset int64) ([]ListOrganisationLocationsWithinRow, error) {
+	g, err := queryGeometry(g, LocationGeoColumn)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListOrganisationLocationsWithin(ctx, ListOrganisationLocationsWithinParams{
+		Limit:          limit,
+		Offset:         offset,
+		OrganisationID: sql.NullInt64{Int64: organisationID, Valid: true},
+		Geo:            g,
+	})
+}
+
+// Get the area covered by the locations of an organisation, as the union of their
+// geometry. An organisation with no locations covers an empty GeometryCollection.
+func (store *Store) OrganisationCoverage(ctx context.Context, organisationID int64) (geo.GISGeometry, error) {
+	return store.GetOrganisationCoverage(ctx, sql.NullInt64{Int64: organisationID, Valid: true})
+}
+
+// Get the convex hull of the locations of an organisation. The hull of a single
+// location is a Point, and of two a LineString. An organisation with no
+// locations has an empty GeometryCollection hull.
+func (store *Store) OrganisationConvexHull(ctx context.Context, organisationID int64) (geo.GISGeometry, error) {
+	return store.GetOrganisationConvexHull(ctx, sql.NullInt64{Int64: organisationID, Valid: true})
+}
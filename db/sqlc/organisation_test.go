@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestOrganisationCoverage(t *testing.T) {
+	requirePostGIS(t)
+	store := NewStore(testDB)
+
+	arg := makeTestLocationParams(t)
+	organisationID := arg.OrganisationID.Int64
+
+	hull, err := store.OrganisationConvexHull(context.Background(), organisationID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hull.GeoType != geo.GeometryCollectionType {
+		t.Errorf("convex hull of no locations was %v, expected empty GeometryCollection", hull)
+	}
+
+	corners := [][]float64{{0, 0}, {0, 1}, {1, 1}, {1, 0}}
+	for _, corner := range corners {
+		point, err := geo.NewPoint(corner, geo.XY)
+		if err != nil {
+			t.Fatal(err)
+		}
+		arg.Geo = geo.NewGISGeometry(point)
+		arg.Geo.SetSRID(4326)
+		if _, err := store.CreateLocation(context.Background(), arg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	coverage, err := store.OrganisationCoverage(context.Background(), organisationID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coverage.GeoType != geo.MultiPointType {
+		t.Errorf("coverage of point locations was %v, expected MultiPoint", coverage)
+	}
+
+	hull, err = store.OrganisationConvexHull(context.Background(), organisationID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hull.GeoType != geo.PolygonType {
+		t.Errorf("convex hull of square locations was %v, expected Polygon", hull)
+	}
+
+	box, err := geo.NewBox2D(-0.5, -0.5, 0.5, 1.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	within, err := box.Polygon()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := store.OrganisationLocationsWithin(context.Background(), organisationID, geo.NewGISGeometry(within), 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Errorf("expected 2 organisation locations within %v, got %v", box, len(rows))
+	}
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+// Get the locations intersecting the geometry, such as those along a route
+func (store *Store) LocationsIntersecting(ctx context.Context, g geo.GISGeometry, limit int64, offset int64) ([]ListLocationsIntersectingRow, error) {
+	g, err := queryGeometry(g, LocationGeoColumn)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListLocationsIntersecting(ctx, ListLocationsIntersectingParams{Limit: limit, Offset: offset, Geo: g})
+}
+
+// Get the locations containing the geometry
+func (store *Store) LocationsContaining(ctx context.Context, g geo.GISGeometry, limit int64, offset int64) ([]ListLocationsContainingRow, error) {
+	g, err := queryGeometry(g, LocationGeoColumn)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListLocationsContaining(ctx, ListLocationsContainingParams{Limit: limit, Offset: offset, Geo: g})
+}
+
+// Get the locations within the geometry, such as those inside a map viewport.
+// Locations on the boundary of the geometry are not within it.
+func (store *Store) LocationsWithin(ctx context.Context, g geo.GISGeometry, limit int64, offset int64) ([]ListLocationsWithinRow, error) {
+	g, err := queryGeometry(g, LocationGeoColumn)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListLocationsWithin(ctx, ListLocationsWithinParams{Limit: limit, Offset: offset, Geo: g})
+}
+
+// Get the locations whose bounding box intersects the bounding box of the
+// geometry. This only uses the spatial index, so is faster but less exact than
+// LocationsIntersecting.
+func (store *Store) LocationsInBox(ctx context.Context, g geo.GISGeometry, limit int64, offset int64) ([]ListLocationsInBoxRow, error) {
+	g, err := queryGeometry(g, LocationGeoColumn)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListLocationsInBox(ctx, ListLocationsInBoxParams{Limit: limit, Offset: offset, Geo: g})
+}
+
+// Get the gisdata rows intersecting the geometry
+func (store *Store) GISDataIntersecting(ctx context.Context, g geo.GISGeometry, limit int64, offset int64) ([]ListGISDataIntersectingRow, error) {
+	g, err := queryGeometry(g, GISDataGeoColumn)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListGISDataIntersecting(ctx, ListGISDataIntersectingParams{Limit: limit, Offset: offset, Geo: g})
+}
+
+// Get the gisdata rows containing the geometry, such as the service areas
+// containing a point
+func (store *Store) GISDataContaining(ctx context.Context, g geo.GISGeometry, limit int64, offset int64) ([]ListGISDataContainingRow, error) {
+	g, err := queryGeometry(g, GISDataGeoColumn)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListGISDataContaining(ctx, ListGISDataContainingParams{Limit: limit, Offset: offset, Geo: g})
+}
+
+// Get the gisdata rows within the geometry
+func (store *Store) GISDataWithin(ctx context.Context, g geo.GISGeometry, limit int64, offset int64) ([]ListGISDataWithinRow, error) {
+	g, err := queryGeometry(g, GISDataGeoColumn)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListGISDataWithin(ctx, ListGISDataWithinParams{Limit: limit, Offset: offset, Geo: g})
+}
+
+// Get the gisdata rows whose bounding box intersects the bounding box of the
+// geometry
+func (store *Store) GISDataInBox(ctx context.Context, g geo.GISGeometry, limit int64, offset int64) ([]ListGISDataInBoxRow, error) {
+	g, err := queryGeometry(g, GISDataGeoColumn)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListGISDataInBox(ctx, ListGISDataInBoxParams{Limit: limit, Offset: offset, Geo: g})
+}
+
+// Prepare a geometry to compare with a column. PostGIS will not compare
+// geometry in different SRIDs, so geometry without an SRID takes the column
+// SRID, and geometry in another SRID is rejected.
+func queryGeometry(g geo.GISGeometry, column geo.ColumnType) (geo.GISGeometry, error) {
+	if g.Geometry == nil {
+		return g, fmt.Errorf("no query geometry provided")
+	}
+	if !g.SRIDFlag {
+		g.SetSRID(column.SRID)
+	}
+	if g.SRID != column.SRID {
+		return g, fmt.Errorf("query geometry srid %v does not match %v", g.SRID, column)
+	}
+	return g, nil
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestLocationsWithin(t *testing.T) {
+	store := NewStore(testDB)
+
+	point, err := geo.NewPoint([]float64{10.5, 20.5}, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := geo.NewGISGeometry(point)
+	g.SetSRID(4326)
+	location, err := store.CreateLocation(context.Background(), CreateLocationParams{Geo: g})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	box, err := geo.NewBox2D(10, 20, 11, 21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	viewport, err := box.Polygon()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := store.LocationsWithin(context.Background(), geo.NewGISGeometry(viewport), 1000, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, row := range rows {
+		found = found || row.ID == location.ID
+	}
+	if !found {
+		t.Errorf("location %v was not found within %v", location.ID, box)
+	}
+
+	intersecting, err := store.LocationsIntersecting(context.Background(), geo.NewGISGeometry(viewport), 1000, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(intersecting) < len(rows) {
+		t.Errorf("%v locations intersect %v, fewer than the %v within it", len(intersecting), box, len(rows))
+	}
+}
+
+func TestQueryGeometry(t *testing.T) {
+	point, err := geo.NewPoint([]float64{1, 2}, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := queryGeometry(geo.NewGISGeometry(point), LocationGeoColumn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.SRIDFlag || g.SRID != LocationGeoColumn.SRID {
+		t.Errorf("query geometry srid %v was not set to the column srid %v", g.SRID, LocationGeoColumn.SRID)
+	}
+
+	other := geo.NewGISGeometry(point)
+	other.SetSRID(3857)
+	if _, err := queryGeometry(other, LocationGeoColumn); err == nil {
+		t.Errorf("query geometry in srid 3857 was accepted for %v", LocationGeoColumn)
+	}
+
+	if _, err := queryGeometry(geo.GISGeometry{}, GISDataGeoColumn); err == nil {
+		t.Errorf("missing query geometry was accepted")
+	}
+}
@@ -3,7 +3,11 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
+
+	"github.com/stephenirven/go-postgis/geo"
 )
 
 // embeds queries struct, and provides transaction support
@@ -29,10 +33,126 @@ func (store *Store) execTx(ctx context.Context, fn func(*Queries) error) error {
 	if err != nil {
 		// failed query - rollback transaction
 		if rbErr := tx.Rollback(); rbErr != nil {
-			return fmt.Errorf("tx error: %v, rollback error: %v", err, rbErr)
+			return fmt.Errorf("tx error: %w, rollback error: %v", err, rbErr)
 		}
-		return fmt.Errorf("tx error: %v", err)
+		return fmt.Errorf("tx error: %w", err)
 	}
 	return tx.Commit()
 
 }
+
+// Input parameters for MoveLocation
+type MoveLocationParams struct {
+	ID  int64           `json:"id"`
+	Geo geo.GISGeometry `json:"geo"`
+}
+
+// Result of MoveLocation
+type MoveLocationResult struct {
+	From     geo.GISGeometry `json:"from"`
+	Location Location        `json:"location"`
+}
+
+// Move a location to new geometry. The location is locked while it is moved,
+// and the geometry it was moved from is returned.
+func (store *Store) MoveLocation(ctx context.Context, arg MoveLocationParams) (MoveLocationResult, error) {
+	var result MoveLocationResult
+
+	if err := LocationGeoColumn.Check(arg.Geo); err != nil {
+		return result, err
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		location, err := q.GetLocationForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		result.From = location.Geo
+
+		result.Location, err = q.UpdateLocationGeo(ctx, UpdateLocationGeoParams{Geo: arg.Geo, ID: arg.ID})
+		return err
+	})
+
+	return result, err
+}
+
+// Errors returned by Book when a booking cannot be made
+var (
+	ErrBookingUnavailable = errors.New("bookable does not have the capacity free for the booking")
+	ErrBookingLength      = errors.New("booking length is not allowed for the bookable")
+)
+
+// Input parameters for Book
+type BookParams struct {
+	BookableID int64     `json:"bookable_id"`
+	BookedBy   int64     `json:"booked_by"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	Capacity   int64     `json:"capacity"`
+}
+
+// Book capacity of a bookable between the start and end times. The bookable is
+// locked while its availability is checked, so concurrent bookings cannot
+// overbook it. The capacity in use at any one time, with the clear time of the
+// bookable after each booking, must leave enough free, and bookings must be
+// of an allowed booking length.
+func (store *Store) Book(ctx context.Context, arg BookParams) (Booking, error) {
+	var booking Booking
+
+	if !arg.StartTime.Before(arg.EndTime) {
+		return booking, fmt.Errorf("booking start %v must be before end %v", arg.StartTime, arg.EndTime)
+	}
+	if arg.Capacity < 1 {
+		return booking, fmt.Errorf("booking capacity %v must be at least 1", arg.Capacity)
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetBookableForUpdate(ctx, arg.BookableID)
+		if err != nil {
+			return err
+		}
+
+		availability, err := q.GetBookableAvailability(ctx, GetBookableAvailabilityParams{
+			StartTime:  arg.StartTime,
+			EndTime:    arg.EndTime,
+			BookableID: arg.BookableID,
+		})
+		if err != nil {
+			return err
+		}
+		if !availability.LengthAllowed {
+			return ErrBookingLength
+		}
+		if availability.FreeCapacity < arg.Capacity {
+			return ErrBookingUnavailable
+		}
+
+		booking, err = q.CreateBooking(ctx, CreateBookingParams{
+			BookableID: sql.NullInt64{Int64: arg.BookableID, Valid: true},
+			BookedBy:   arg.BookedBy,
+			StartTime:  arg.StartTime,
+			EndTime:    arg.EndTime,
+			Capacity:   arg.Capacity,
+		})
+		return err
+	})
+
+	return booking, err
+}
+
+// Get up to n bookables within metres of the point with the capacity free between
+// the start and end times, nearest first. The point is longitude/latitude.
+func (store *Store) AvailableBookablesNear(ctx context.Context, point geo.Point, metres float64, start time.Time, end time.Time, capacity int64, n int64) ([]ListAvailableBookablesNearRow, error) {
+	g, err := geo.NewGISGeography(&point)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListAvailableBookablesNear(ctx, ListAvailableBookablesNearParams{
+		Point:     g,
+		StartTime: start,
+		EndTime:   end,
+		Metres:    metres,
+		Capacity:  capacity,
+		Count:     n,
+	})
+}
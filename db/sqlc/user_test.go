@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUser(t *testing.T) {
+	organisation, err := testQueries.CreateOrganisation(context.Background(), CreateOrganisationParams{
+		MerchantName: sql.NullString{String: "Test Merchant", Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := makeTestUser(t, organisation.ID)
+
+	got, err := testQueries.GetUserByEmail(context.Background(), user.Email)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(GetUserByEmailRow(user), got) {
+		t.Errorf("user %+v was not equal to %+v", got, user)
+	}
+
+	// Only the credentials query returns the password hash
+	credentials, err := testQueries.GetUserCredentials(context.Background(), user.Email)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if credentials.ID != user.ID || credentials.EncryptedPassword.String != "encrypted" {
+		t.Errorf("credentials %+v were not those of user %v", credentials, user.ID)
+	}
+
+	updated, err := testQueries.UpdateUser(context.Background(), UpdateUserParams{
+		ID:             user.ID,
+		FullName:       sql.NullString{String: "Updated User", Valid: true},
+		Email:          user.Email,
+		Mobile:         user.Mobile,
+		OrganisationID: user.OrganisationID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.FullName.String != "Updated User" || updated.Email != user.Email {
+		t.Errorf("user %+v was not updated from %+v", updated, user)
+	}
+
+	users, err := testQueries.ListOrganisationUsers(context.Background(), ListOrganisationUsersParams{
+		OrganisationID: user.OrganisationID,
+		Limit:          10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].ID != user.ID {
+		t.Errorf("organisation users %+v were not [%v]", users, user.ID)
+	}
+
+	if err := testQueries.DeleteUser(context.Background(), user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := testQueries.GetUser(context.Background(), user.ID); err != sql.ErrNoRows {
+		t.Errorf("expected %v getting deleted user, got %v", sql.ErrNoRows, err)
+	}
+}
+
+// Create a user belonging to the organisation
+func makeTestUser(t *testing.T, organisationID int64) CreateUserRow {
+	user, err := testQueries.CreateUser(context.Background(), CreateUserParams{
+		FullName:          sql.NullString{String: "Test User", Valid: true},
+		Email:             sql.NullString{String: fmt.Sprintf("user%v@example.com", rand.Int63()), Valid: true},
+		EncryptedPassword: sql.NullString{String: "encrypted", Valid: true},
+		Mobile:            sql.NullString{String: "07700900000         ", Valid: true},
+		OrganisationID:    sql.NullInt64{Int64: organisationID, Valid: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
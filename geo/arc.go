@@ -0,0 +1,195 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+	https://postgis.net/docs/ST_CurveToLine.html
+
+Each arc of a CircularString passes through three points: its start, a point
+on the arc and its end, with the end of one arc starting the next. An arc whose
+start and end are the same is a full circle, with the middle point opposite
+the start. Arcs of collinear points are straight lines.
+
+Curves are linearized as ST_CurveToLine does, with a number of segments for
+each quarter circle, and Z and M interpolated along the arc through the
+middle point.
+*/
+
+// Segments for each quarter circle used by ST_CurveToLine
+const DefaultSegmentsPerQuarter = 32
+
+// A circular arc through three points
+type arc struct {
+	center [2]float64
+	radius float64
+	start  float64 // angle of the first point
+	sweep  float64 // angle from the first point to the last, positive anticlockwise
+	middle float64 // angle from the first point to the middle point
+}
+
+// Get the arc through three points, or false if the points are collinear
+func arcThrough(p0 []float64, p1 []float64, p2 []float64) (arc, bool) {
+	if p0[0] == p2[0] && p0[1] == p2[1] {
+		if p0[0] == p1[0] && p0[1] == p1[1] {
+			return arc{}, false
+		}
+		// A full circle, with the middle point opposite the start
+		center := [2]float64{(p0[0] + p1[0]) / 2, (p0[1] + p1[1]) / 2}
+		return arc{
+			center: center,
+			radius: math.Hypot(p0[0]-center[0], p0[1]-center[1]),
+			start:  math.Atan2(p0[1]-center[1], p0[0]-center[0]),
+			sweep:  2 * math.Pi,
+			middle: math.Pi,
+		}, true
+	}
+
+	d := 2 * (p0[0]*(p1[1]-p2[1]) + p1[0]*(p2[1]-p0[1]) + p2[0]*(p0[1]-p1[1]))
+	if d == 0 {
+		return arc{}, false
+	}
+	s0 := p0[0]*p0[0] + p0[1]*p0[1]
+	s1 := p1[0]*p1[0] + p1[1]*p1[1]
+	s2 := p2[0]*p2[0] + p2[1]*p2[1]
+	center := [2]float64{
+		(s0*(p1[1]-p2[1]) + s1*(p2[1]-p0[1]) + s2*(p0[1]-p1[1])) / d,
+		(s0*(p2[0]-p1[0]) + s1*(p0[0]-p2[0]) + s2*(p1[0]-p0[0])) / d,
+	}
+
+	a := arc{center: center, radius: math.Hypot(p0[0]-center[0], p0[1]-center[1])}
+	a.start = math.Atan2(p0[1]-center[1], p0[0]-center[0])
+	a1 := math.Atan2(p1[1]-center[1], p1[0]-center[0])
+	a2 := math.Atan2(p2[1]-center[1], p2[0]-center[0])
+
+	// The arc turns left, anticlockwise, if the middle point is left of the chord
+	if (p1[0]-p0[0])*(p2[1]-p0[1])-(p1[1]-p0[1])*(p2[0]-p0[0]) > 0 {
+		a.sweep, a.middle = normalizeAngle(a2-a.start), normalizeAngle(a1-a.start)
+	} else {
+		a.sweep, a.middle = -normalizeAngle(a.start-a2), -normalizeAngle(a.start-a1)
+	}
+	return a, true
+}
+
+// Get an angle in the range [0, 2π)
+func normalizeAngle(a float64) float64 {
+	a = math.Mod(a, 2*math.Pi)
+	if a < 0 {
+		a += 2 * math.Pi
+	}
+	return a
+}
+
+// Get the XY point of the arc at an angle from its start
+func (a arc) at(angle float64) (float64, float64) {
+	return a.center[0] + a.radius*math.Cos(a.start+angle), a.center[1] + a.radius*math.Sin(a.start+angle)
+}
+
+// Append the points of the arc from p0 to p2 through p1 after p0, in
+// segments of at most a quarter circle divided by segmentsPerQuarter
+func appendArc(coords []float64, p0 []float64, p1 []float64, p2 []float64, segmentsPerQuarter int) []float64 {
+	a, ok := arcThrough(p0, p1, p2)
+	if !ok {
+		if coordsEqual(p0, p1) || coordsEqual(p1, p2) {
+			return append(coords, p2...)
+		}
+		return append(append(coords, p1...), p2...)
+	}
+
+	n := int(math.Ceil(math.Abs(a.sweep) / (math.Pi / 2 / float64(segmentsPerQuarter))))
+	for i := 1; i < n; i++ {
+		angle := a.sweep * float64(i) / float64(n)
+		x, y := a.at(angle)
+
+		// Interpolate the other ordinates between the points either side
+		from, to, t := p0, p1, angle/a.middle
+		if math.Abs(angle) > math.Abs(a.middle) {
+			from, to, t = p1, p2, (angle-a.middle)/(a.sweep-a.middle)
+		}
+		coords = append(coords, x, y)
+		for j := 2; j < len(p0); j++ {
+			coords = append(coords, from[j]+t*(to[j]-from[j]))
+		}
+	}
+	return append(coords, p2...)
+}
+
+// Get the coordinates of a curve as a single point sequence, with arcs
+// linearized
+func linearizeCurve(g GeometrySubtype, segmentsPerQuarter int) ([]float64, error) {
+	if segmentsPerQuarter <= 0 {
+		segmentsPerQuarter = DefaultSegmentsPerQuarter
+	}
+	switch t := g.(type) {
+	case *LineString:
+		return append([]float64(nil), t.Coords...), nil
+	case *CircularString:
+		if t.NumPoints() == 0 {
+			return nil, nil
+		}
+		if t.NumPoints() < 3 || t.NumPoints()%2 == 0 {
+			return nil, fmt.Errorf("circularstring must have an odd number of points, 3 or greater")
+		}
+		coords := append([]float64(nil), t.Coord(0)...)
+		for i := 2; i < t.NumPoints(); i += 2 {
+			coords = appendArc(coords, t.Coord(i-2), t.Coord(i-1), t.Coord(i), segmentsPerQuarter)
+		}
+		return coords, nil
+	case *CompoundCurve:
+		var coords []float64
+		stride := t.Dimensions.Stride()
+		for _, component := range t.Geometry {
+			c, err := linearizeCurve(component, segmentsPerQuarter)
+			if err != nil {
+				return nil, err
+			}
+			// Components start where the previous one ends
+			if len(coords) >= stride && len(c) >= stride && coordsEqual(coords[len(coords)-stride:], c[:stride]) {
+				c = c[stride:]
+			}
+			coords = append(coords, c...)
+		}
+		return coords, nil
+	default:
+		return nil, fmt.Errorf("%v is not a curve", g.GetGISGeometryType())
+	}
+}
+
+// Get the CircularString as a LineString, with each quarter circle of its arcs
+// divided into segmentsPerQuarter segments, or DefaultSegmentsPerQuarter if
+// not positive
+func (c CircularString) Linearize(segmentsPerQuarter int) (*LineString, error) {
+	coords, err := linearizeCurve(&c, segmentsPerQuarter)
+	if err != nil {
+		return nil, err
+	}
+	return &LineString{Coords: coords, Dimensions: c.Dimensions}, nil
+}
+
+// Get the CompoundCurve as a LineString, with each quarter circle of its arcs
+// divided into segmentsPerQuarter segments, or DefaultSegmentsPerQuarter if
+// not positive
+func (c CompoundCurve) Linearize(segmentsPerQuarter int) (*LineString, error) {
+	coords, err := linearizeCurve(&c, segmentsPerQuarter)
+	if err != nil {
+		return nil, err
+	}
+	return &LineString{Coords: coords, Dimensions: c.Dimensions}, nil
+}
+
+// Get the CurvePolygon as a Polygon, with each quarter circle of the arcs of
+// its rings divided into segmentsPerQuarter segments, or
+// DefaultSegmentsPerQuarter if not positive
+func (c CurvePolygon) Linearize(segmentsPerQuarter int) (*Polygon, error) {
+	p := Polygon{Dimensions: c.Dimensions}
+	for _, ring := range c.Geometry {
+		coords, err := linearizeCurve(ring, segmentsPerQuarter)
+		if err != nil {
+			return nil, err
+		}
+		p.LinearRings = append(p.LinearRings, LinearRing{Coords: coords, Dimensions: c.Dimensions})
+	}
+	return &p, nil
+}
@@ -0,0 +1,75 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestCircularStringLinearize(t *testing.T) {
+	// A half circle of radius 1 about (1, 0), with Z rising along it
+	cs := geo.CircularString{Coords: []float64{0, 0, 0, 1, 1, 1, 2, 0, 2}, Dimensions: geo.XYZ}
+	ls, err := cs.Linearize(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ls.NumPoints() != 5 {
+		t.Fatalf("expected 5 points, got %v", ls)
+	}
+	for i := 0; i < ls.NumPoints(); i++ {
+		c := ls.Coord(i)
+		if r := math.Hypot(c[0]-1, c[1]); math.Abs(r-1) > 1e-9 {
+			t.Errorf("point %v is not on the arc", c)
+		}
+		if math.Abs(c[2]-float64(i)/2) > 1e-9 {
+			t.Errorf("expected Z of %v at point %v, got %v", float64(i)/2, i, c[2])
+		}
+	}
+	if !cmp.Equal(ls.Coord(0), cs.Coord(0)) || !cmp.Equal(ls.Coord(4), cs.Coord(2)) {
+		t.Errorf("linestring %v does not end at the ends of the arc", ls)
+	}
+
+	// Collinear points are a straight line
+	straight := geo.CircularString{Coords: []float64{0, 0, 1, 1, 2, 2}, Dimensions: geo.XY}
+	if ls, err := straight.Linearize(0); err != nil || !cmp.Equal(ls.Coords, straight.Coords) {
+		t.Errorf("expected straight line, got %v, %v", ls, err)
+	}
+
+	if _, err := (geo.CircularString{Coords: []float64{0, 0, 1, 1}, Dimensions: geo.XY}).Linearize(0); err == nil {
+		t.Error("expected error linearizing circularstring of 2 points")
+	}
+}
+
+func TestCompoundCurveLinearize(t *testing.T) {
+	cc := geo.CompoundCurve{Geometry: []geo.GeometrySubtype{
+		&geo.CircularString{Coords: []float64{0, 0, 1, 1, 2, 0}, Dimensions: geo.XY},
+		&geo.LineString{Coords: []float64{2, 0, 4, 0}, Dimensions: geo.XY},
+	}, Dimensions: geo.XY}
+	ls, err := cc.Linearize(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{0, 0, 1, 1, 2, 0, 4, 0}
+	if !cmp.Equal(ls.Coords, expected, cmp.Comparer(func(a, b float64) bool { return math.Abs(a-b) < 1e-9 })) {
+		t.Errorf("expected %v, got %v", expected, ls.Coords)
+	}
+}
+
+func TestCurvePolygonLinearize(t *testing.T) {
+	// A circle of radius 1 about (1, 0)
+	cp := geo.CurvePolygon{Geometry: []geo.GeometrySubtype{
+		&geo.CircularString{Coords: []float64{0, 0, 2, 0, 0, 0}, Dimensions: geo.XY},
+	}, Dimensions: geo.XY}
+	p, err := cp.Linearize(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := p.LinearRings[0].NumPoints(); n != 4*geo.DefaultSegmentsPerQuarter+1 {
+		t.Errorf("expected %v points, got %v", 4*geo.DefaultSegmentsPerQuarter+1, n)
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("linearized circle is not valid: %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package geo
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+	https://postgis.net/docs/box2d_type.html
+
+A Box2D is a 2-dimensional bounding box, as returned by ST_Extent and the
+box2d cast. PostGIS represents a box2d as text in the form
+BOX(xmin ymin,xmax ymax).
+*/
+type Box2D struct {
+	MinX float64
+	MinY float64
+	MaxX float64
+	MaxY float64
+}
+
+// Create a Box2D from minimum and maximum coordinates.
+// Minimums must not be greater than maximums.
+func NewBox2D(minX, minY, maxX, maxY float64) (*Box2D, error) {
+	if minX > maxX || minY > maxY {
+		return nil, fmt.Errorf("box2d minimum (%v %v) is greater than maximum (%v %v)", minX, minY, maxX, maxY)
+	}
+	return &Box2D{MinX: minX, MinY: minY, MaxX: maxX, MaxY: maxY}, nil
+}
+
+// Stringer interface - PostGIS text representation
+func (b Box2D) String() string {
+	return "BOX(" + formatBoxCoords(b.MinX, b.MinY) + "," + formatBoxCoords(b.MaxX, b.MaxY) + ")"
+}
+
+// Get the envelope of the box as an XY Polygon, with the single ring ordered
+// (minx miny, minx maxy, maxx maxy, maxx miny, minx miny) as ST_Envelope does.
+func (b Box2D) Polygon() (*Polygon, error) {
+	ring, err := LinearRingFromCoords([]float64{
+		b.MinX, b.MinY,
+		b.MinX, b.MaxY,
+		b.MaxX, b.MaxY,
+		b.MaxX, b.MinY,
+		b.MinX, b.MinY,
+	}, XY)
+	if err != nil {
+		return nil, err
+	}
+	return NewPolygon([]LinearRing{*ring})
+}
+
+// Create a Box2D bounding the exterior ring of a Polygon
+func Box2DFromPolygon(p *Polygon) (*Box2D, error) {
+	if p == nil {
+		return nil, fmt.Errorf("polygon is nil")
+	}
+	if len(p.LinearRings) == 0 || p.LinearRings[0].NumPoints() == 0 {
+		return nil, fmt.Errorf("polygon has no exterior ring to bound")
+	}
+	b := Box2D{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+	shell := p.LinearRings[0]
+	for i := 0; i < shell.NumPoints(); i++ {
+		c := shell.Coord(i)
+		b.MinX, b.MaxX = math.Min(b.MinX, c[0]), math.Max(b.MaxX, c[0])
+		b.MinY, b.MaxY = math.Min(b.MinY, c[1]), math.Max(b.MaxY, c[1])
+	}
+	return &b, nil
+}
+
+// Used to generate a database/sql/driver.Value to write
+func (b Box2D) Value() (driver.Value, error) {
+	return b.String(), nil
+}
+
+// Used to map box2d values into structs when read by the database driver
+func (b *Box2D) Scan(value interface{}) error {
+	text, err := boxText(value)
+	if err != nil {
+		return err
+	}
+	coords, err := parseBox(text, "BOX", 2)
+	if err != nil {
+		return err
+	}
+	*b = Box2D{MinX: coords[0], MinY: coords[1], MaxX: coords[2], MaxY: coords[3]}
+	return nil
+}
+
+// NullBox2D represents a Box2D that may be null, such as the ST_Extent of no rows.
+type NullBox2D struct {
+	Box2D Box2D
+	Valid bool // Valid is true if Box2D is not NULL
+}
+
+// Used to generate a database/sql/driver.Value to write
+func (n NullBox2D) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Box2D.Value()
+}
+
+// Used to map nullable box2d values into structs when read by the database driver
+func (n *NullBox2D) Scan(value interface{}) error {
+	if value == nil {
+		n.Box2D, n.Valid = Box2D{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Box2D.Scan(value)
+}
+
+// Get the text of a box value from the database driver
+func boxText(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case []byte:
+		return string(v), nil
+	case string:
+		return v, nil
+	default:
+		return "", fmt.Errorf("scan expected []byte or string, got %T (%v)", value, value)
+	}
+}
+
+// Parse PostGIS box text of the form PREFIX(min ordinates,max ordinates), returning
+// the minimum ordinates followed by the maximum ordinates.
+func parseBox(text string, prefix string, dimensions int) ([]float64, error) {
+	text = strings.TrimSpace(text)
+	if len(text) < len(prefix)+2 || !strings.EqualFold(text[:len(prefix)+1], prefix+"(") || text[len(text)-1] != ')' {
+		return nil, fmt.Errorf("%v text must be of the form %v(min,max): %q", strings.ToLower(prefix), prefix, text)
+	}
+	corners := strings.Split(text[len(prefix)+1:len(text)-1], ",")
+	if len(corners) != 2 {
+		return nil, fmt.Errorf("%v text must contain 2 corners: %q", strings.ToLower(prefix), text)
+	}
+
+	coords := make([]float64, 0, 2*dimensions)
+	for _, corner := range corners {
+		ordinates := strings.Fields(corner)
+		if len(ordinates) != dimensions {
+			return nil, fmt.Errorf("%v corner must have %v ordinates: %q", strings.ToLower(prefix), dimensions, corner)
+		}
+		for _, o := range ordinates {
+			f, err := strconv.ParseFloat(o, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %v ordinate %q: %w", strings.ToLower(prefix), o, err)
+			}
+			coords = append(coords, f)
+		}
+	}
+	return coords, nil
+}
+
+// Format ordinates separated by spaces, as PostGIS box text
+func formatBoxCoords(ordinates ...float64) string {
+	parts := make([]string, len(ordinates))
+	for i, o := range ordinates {
+		parts[i] = strconv.FormatFloat(o, 'f', -1, 64)
+	}
+	return strings.Join(parts, " ")
+}
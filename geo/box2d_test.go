@@ -0,0 +1,65 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestBox2D(t *testing.T) {
+
+	box := geo.Box2D{}
+	err := box.Scan([]byte("BOX(-1.5 2,3 4.25)"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected, err := geo.NewBox2D(-1.5, 2, 3, 4.25)
+	if err != nil {
+		t.Error(err)
+	}
+	if !cmp.Equal(&box, expected) {
+		t.Errorf("box2d %v was not equal to box2d %v", box, expected)
+	}
+
+	value, err := box.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if value != "BOX(-1.5 2,3 4.25)" {
+		t.Errorf("box2d value %v was not BOX(-1.5 2,3 4.25)", value)
+	}
+
+	polygon, err := box.Polygon()
+	if err != nil {
+		t.Error(err)
+	}
+	box2, err := geo.Box2DFromPolygon(polygon)
+	if err != nil {
+		t.Error(err)
+	}
+	if !cmp.Equal(&box, box2) {
+		t.Errorf("box2d %v was not equal to box2d %v", box, box2)
+	}
+
+	_, err = geo.Box2DFromPolygon(nil)
+	if err == nil {
+		t.Error("box2d created from nil polygon")
+	}
+
+	for _, text := range []string{"BOX(1 2)", "BOX3D(1 2 3,4 5 6)", "BOX(1 2,3 x)", "POLYGON((0 0,1 1))"} {
+		if err := box.Scan(text); err == nil {
+			t.Errorf("box2d scanned invalid text %q", text)
+		}
+	}
+
+	nullBox := geo.NullBox2D{}
+	err = nullBox.Scan(nil)
+	if err != nil {
+		t.Error(err)
+	}
+	if nullBox.Valid {
+		t.Error("null box2d was valid")
+	}
+}
@@ -0,0 +1,112 @@
+package geo
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+)
+
+/*
+	https://postgis.net/docs/box3d_type.html
+
+A Box3D is a 3-dimensional bounding box, as returned by ST_3DExtent and the
+box3d cast. PostGIS represents a box3d as text in the form
+BOX3D(xmin ymin zmin,xmax ymax zmax).
+*/
+type Box3D struct {
+	MinX float64
+	MinY float64
+	MinZ float64
+	MaxX float64
+	MaxY float64
+	MaxZ float64
+}
+
+// Create a Box3D from minimum and maximum coordinates.
+// Minimums must not be greater than maximums.
+func NewBox3D(minX, minY, minZ, maxX, maxY, maxZ float64) (*Box3D, error) {
+	if minX > maxX || minY > maxY || minZ > maxZ {
+		return nil, fmt.Errorf("box3d minimum (%v %v %v) is greater than maximum (%v %v %v)", minX, minY, minZ, maxX, maxY, maxZ)
+	}
+	return &Box3D{MinX: minX, MinY: minY, MinZ: minZ, MaxX: maxX, MaxY: maxY, MaxZ: maxZ}, nil
+}
+
+// Stringer interface - PostGIS text representation
+func (b Box3D) String() string {
+	return "BOX3D(" + formatBoxCoords(b.MinX, b.MinY, b.MinZ) + "," + formatBoxCoords(b.MaxX, b.MaxY, b.MaxZ) + ")"
+}
+
+// Get the 2D footprint of the box
+func (b Box3D) Box2D() Box2D {
+	return Box2D{MinX: b.MinX, MinY: b.MinY, MaxX: b.MaxX, MaxY: b.MaxY}
+}
+
+// Get the envelope of the box footprint as an XY Polygon. The Z range is not
+// represented, as with ST_Envelope.
+func (b Box3D) Polygon() (*Polygon, error) {
+	return b.Box2D().Polygon()
+}
+
+// Create a Box3D bounding the exterior ring of a Polygon with Z coordinates
+func Box3DFromPolygon(p *Polygon) (*Box3D, error) {
+	if p == nil {
+		return nil, fmt.Errorf("polygon is nil")
+	}
+	if p.Dimensions != XYZ && p.Dimensions != XYZM {
+		return nil, fmt.Errorf("polygon of dimensions %v has no z coordinates to bound", p.Dimensions)
+	}
+	b2, err := Box2DFromPolygon(p)
+	if err != nil {
+		return nil, err
+	}
+	b := Box3D{MinX: b2.MinX, MinY: b2.MinY, MinZ: math.Inf(1), MaxX: b2.MaxX, MaxY: b2.MaxY, MaxZ: math.Inf(-1)}
+	shell := p.LinearRings[0]
+	for i := 0; i < shell.NumPoints(); i++ {
+		z := shell.Coord(i)[2]
+		b.MinZ, b.MaxZ = math.Min(b.MinZ, z), math.Max(b.MaxZ, z)
+	}
+	return &b, nil
+}
+
+// Used to generate a database/sql/driver.Value to write
+func (b Box3D) Value() (driver.Value, error) {
+	return b.String(), nil
+}
+
+// Used to map box3d values into structs when read by the database driver
+func (b *Box3D) Scan(value interface{}) error {
+	text, err := boxText(value)
+	if err != nil {
+		return err
+	}
+	coords, err := parseBox(text, "BOX3D", 3)
+	if err != nil {
+		return err
+	}
+	*b = Box3D{MinX: coords[0], MinY: coords[1], MinZ: coords[2], MaxX: coords[3], MaxY: coords[4], MaxZ: coords[5]}
+	return nil
+}
+
+// NullBox3D represents a Box3D that may be null, such as the ST_3DExtent of no rows.
+type NullBox3D struct {
+	Box3D Box3D
+	Valid bool // Valid is true if Box3D is not NULL
+}
+
+// Used to generate a database/sql/driver.Value to write
+func (n NullBox3D) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Box3D.Value()
+}
+
+// Used to map nullable box3d values into structs when read by the database driver
+func (n *NullBox3D) Scan(value interface{}) error {
+	if value == nil {
+		n.Box3D, n.Valid = Box3D{}, false
+		return nil
+	}
+	n.Valid = true
+	return n.Box3D.Scan(value)
+}
@@ -0,0 +1,59 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestBox3D(t *testing.T) {
+
+	box := geo.Box3D{}
+	err := box.Scan("BOX3D(0 1 -2,3 4 5)")
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected, err := geo.NewBox3D(0, 1, -2, 3, 4, 5)
+	if err != nil {
+		t.Error(err)
+	}
+	if !cmp.Equal(&box, expected) {
+		t.Errorf("box3d %v was not equal to box3d %v", box, expected)
+	}
+
+	value, err := box.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if value != "BOX3D(0 1 -2,3 4 5)" {
+		t.Errorf("box3d value %v was not BOX3D(0 1 -2,3 4 5)", value)
+	}
+
+	ring, err := geo.LinearRingFromCoords([]float64{0, 1, -2, 0, 4, 0, 3, 4, 5, 0, 1, -2}, geo.XYZ)
+	if err != nil {
+		t.Error(err)
+	}
+	polygon, err := geo.NewPolygon([]geo.LinearRing{*ring})
+	if err != nil {
+		t.Error(err)
+	}
+	box2, err := geo.Box3DFromPolygon(polygon)
+	if err != nil {
+		t.Error(err)
+	}
+	if !cmp.Equal(&box, box2) {
+		t.Errorf("box3d %v was not equal to box3d %v", box, box2)
+	}
+
+	_, err = geo.Box3DFromPolygon(makeTestPolygon(t))
+	if err == nil {
+		t.Error("box3d created from XY polygon")
+	}
+
+	_, err = geo.Box3DFromPolygon(nil)
+	if err == nil {
+		t.Error("box3d created from nil polygon")
+	}
+}
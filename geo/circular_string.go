@@ -19,7 +19,7 @@ next arc, just like the segments of a LineString. This means that a CircularStri
 an odd number of points greater than 1.
 */
 type CircularString struct {
-	Points     []Point
+	Coords     []float64
 	Dimensions Dimensions
 }
 
@@ -33,20 +33,56 @@ func (c CircularString) String() string {
 	sb.WriteString("(CircularString ")
 	sb.WriteString(c.Dimensions.String())
 	sb.WriteString(" [")
-	for _, p := range c.Points {
-		sb.WriteString(p.String())
-		sb.WriteString(" ")
-	}
+	writeCoordsString(&sb, c.Coords, c.Dimensions)
 
 	sb.WriteString("])")
 	return sb.String()
 }
 
+// Report whether the CircularString equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (c CircularString) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&c, other, o.Tolerance)
+	}
+	d, ok := subtypeAs[CircularString](other)
+	return ok && c.Dimensions == d.Dimensions && sequencesEqual(c.Coords, d.Coords, c.Dimensions, 2, o)
+}
+
 // Get the dimensions of the geometry
 func (c CircularString) GetDimensions() Dimensions {
 	return c.Dimensions
 }
 
+// Get the bounding box of the CircularString in each of its dimensions, bounding its
+// arcs rather than their control points
+func (c CircularString) Envelope() Envelope {
+	e := NewEnvelope(c.Dimensions)
+	e.addArcs(c.Coords)
+	return e.clear()
+}
+
+// Get the number of points in the CircularString
+func (c CircularString) NumPoints() int {
+	return coordCount(c.Coords, c.Dimensions)
+}
+
+// Get the ordinates of the point at index i. The slice shares storage with the CircularString.
+func (c CircularString) Coord(i int) []float64 {
+	return coordAt(c.Coords, c.Dimensions, i)
+}
+
+// Get the point at index i. The point shares storage with the CircularString.
+func (c CircularString) PointN(i int) Point {
+	return Point{Coords: c.Coord(i), Dimensions: c.Dimensions}
+}
+
+// Get the points of the CircularString, arc endpoints and midpoints in turn, each sharing storage with it
+func (c CircularString) Points() []Point {
+	return pointsFromCoords(c.Coords, c.Dimensions)
+}
+
 // Create a new CircularString from input slice of Points
 // A CircularString is specified by three points: the start and end points (first and third)
 // and some other point on the arc.
@@ -56,39 +92,59 @@ func NewCircularString(p []Point) (*CircularString, error) {
 	if len(p)%2 != 1 || len(p) < 3 {
 		return nil, fmt.Errorf("circularstring must contain an odd number of points greater than 1")
 	}
-	c := CircularString{}
-	c.Dimensions = p[0].Dimensions
-	c.Points = p
+	coords, err := flattenPoints(p, p[0].Dimensions)
+	if err != nil {
+		return nil, fmt.Errorf("error creating circularstring, %v", err)
+	}
+	return CircularStringFromCoords(coords, p[0].Dimensions)
+}
+
+// Create a new CircularString from a flat slice of ordinates and Dimensions.
+// The ordinates must describe an odd number of points greater than 1.
+func CircularStringFromCoords(coords []float64, dimensions Dimensions) (*CircularString, error) {
+	if err := checkCoords(coords, dimensions); err != nil {
+		return nil, fmt.Errorf("error creating circularstring, %v", err)
+	}
+	c := CircularString{Coords: coords, Dimensions: dimensions}
+	if c.NumPoints()%2 != 1 || c.NumPoints() < 3 {
+		return nil, fmt.Errorf("circularstring must contain an odd number of points greater than 1")
+	}
 	return &c, nil
 }
 
 // Create Circular String from input byte buffer in EWKB format and dimensions.
 // A CircularString is specified by three points: the start and end points (first and third)
-// and some other point on the arc.
-func CircularStringFromEWKB(b *bytes.Buffer, dimensions Dimensions) (*CircularString, error) {
+// and some other point on the arc,
+// within the limits of the DecodeOptions if given.
+func CircularStringFromEWKB(b *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*CircularString, error) {
+	d, err := newDecodeState(pathName(CircularStringType), b.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return circularStringFromEWKB(b, dimensions, d)
+}
+
+func circularStringFromEWKB(b *bytes.Buffer, dimensions Dimensions, d *decodeState) (*CircularString, error) {
 
 	cs := CircularString{}
 	cs.Dimensions = dimensions
-	if b.Len() < 4 {
-		return nil, fmt.Errorf("byte array for circularstring must be at least length 4")
+	count, err := d.readCount(b, "circularstring")
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return &cs, nil // empty circularstring
 	}
-	count := binary.LittleEndian.Uint32(b.Next(4))
 	if count < 3 || count%2 != 1 {
-		return nil, fmt.Errorf("circularstring must contain an odd number of points greater than 1. %v provided", count)
+		return nil, d.errorAt(d.offset(b)-4, fmt.Errorf("circularstring must contain an odd number of points greater than 1. %v provided", count))
 	}
 
-	// Iterate points in byte slice, adding to struct
-	for i := 0; i < int(count); i++ {
-
-		point, err := PointFromEWKB(b, dimensions)
-		if err != nil {
-			return nil, fmt.Errorf(err.Error())
-		}
-		cs.Points = append(cs.Points, *point)
-	}
-	if len(cs.Points) < 3 || len(cs.Points)%2 != 1 {
-		return nil, fmt.Errorf("circular string must have an odd number of points, 3 or greater")
+	// Read points in byte slice, adding to struct
+	coords, err := d.readCoords(b, dimensions, count)
+	if err != nil {
+		return nil, err
 	}
+	cs.Coords = coords
 
 	return &cs, nil
 }
@@ -105,13 +161,56 @@ func (c CircularString) GetEWKB(includeGeoType bool) bytes.Buffer {
 	}
 
 	// Add an encoded length to the slice
-	lenBytes := binary.LittleEndian.AppendUint32([]byte{}, uint32(len(c.Points)))
+	lenBytes := binary.LittleEndian.AppendUint32([]byte{}, uint32(c.NumPoints()))
 	buf.Write(lenBytes)
 
-	// iterate points to be added to EWKB
-	for _, p := range c.Points {
-		pb := p.GetEWKB(false)
-		pb.WriteTo(buf)
-	}
+	// points are added to EWKB without BOM or geotype
+	writeCoords(buf, c.Coords)
 	return *buf
 }
+
+// Report whether the CircularString is valid, as ST_IsValid
+func (c CircularString) IsValid() bool {
+	return c.Validate() == nil
+}
+
+// Check the CircularString has finite ordinates, and is empty or has an odd
+// number of points of at least 3
+func (c CircularString) Validate() error {
+	return validityError(c.validate(), pathName(CircularStringType))
+}
+
+func (c CircularString) validate() *ValidityError {
+	if e := validateCoords(c.Coords, c.Dimensions, false); e != nil {
+		return e
+	}
+	switch n := c.NumPoints(); {
+	case n == 0:
+		return nil
+	case n < 3:
+		return invalid("Too few points in geometry component", c.Coord(0))
+	case n%2 == 0:
+		return invalid("CircularString must have an odd number of points", c.Coord(0))
+	}
+	return nil
+}
+
+// Get the area of the CircularString, which has none
+func (c CircularString) Area() float64 {
+	return 0
+}
+
+// Get the length of the CircularString, as ST_Length, measuring its arcs exactly
+func (c CircularString) Length() float64 {
+	return arcsLength(c.Coords, c.Dimensions)
+}
+
+// Get the perimeter of the CircularString, which has none
+func (c CircularString) Perimeter() float64 {
+	return 0
+}
+
+// Get the minimum distance between the CircularString and another geometry, as ST_Distance
+func (c CircularString) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&c, other)
+}
@@ -0,0 +1,87 @@
+package geo
+
+import (
+	"fmt"
+)
+
+/*
+	https://postgis.net/docs/using_postgis_dbmanagement.html#Manual_Register_Spatial_Column
+
+A ColumnType describes the type modifier of a geometry column, as in
+geometry(Point, 4326), so geometry can be checked against a column before it
+is written.
+
+A GeoType of UNKNOWN matches any geometry type, as geometry(Geometry, 4326)
+does. As with PostGIS, Dimensions of UNSET are XY, so geometry with Z or M
+ordinates only matches a column declared with them. An SRID of 0 matches any
+SRID. Geometry without an SRID matches any column, as PostGIS applies the
+column SRID to it.
+*/
+type ColumnType struct {
+	GeoType    GISGeometryType
+	Dimensions Dimensions
+	SRID       uint32
+}
+
+// Type names used in geometry column type modifiers
+var typmodNames = map[GISGeometryType]string{
+	UNKNOWN:                "Geometry",
+	PointType:              "Point",
+	LineStringType:         "LineString",
+	PolygonType:            "Polygon",
+	MultiPointType:         "MultiPoint",
+	MultiLineStringType:    "MultiLineString",
+	MultiPolygonType:       "MultiPolygon",
+	GeometryCollectionType: "GeometryCollection",
+	CircularStringType:     "CircularString",
+	CompoundCurveType:      "CompoundCurve",
+	CurvePolygonType:       "CurvePolygon",
+	MultiCurveType:         "MultiCurve",
+	MultiSurfaceType:       "MultiSurface",
+	PolyHedralSurfaceType:  "PolyhedralSurface",
+	TINType:                "Tin",
+	TriangleType:           "Triangle",
+}
+
+// Stringer interface - PostGIS column type, e.g. geometry(PointZ,4326)
+func (c ColumnType) String() string {
+	name := typmodNames[c.GeoType]
+	switch c.dimensions() {
+	case XYZ:
+		name += "Z"
+	case XYM:
+		name += "M"
+	case XYZM:
+		name += "ZM"
+	}
+	if c.SRID == 0 {
+		return "geometry(" + name + ")"
+	}
+	return fmt.Sprintf("geometry(%v,%v)", name, c.SRID)
+}
+
+// Get the dimensions of the column, defaulting to XY
+func (c ColumnType) dimensions() Dimensions {
+	if c.Dimensions == UNSET {
+		return XY
+	}
+	return c.Dimensions
+}
+
+// Check the geometry can be written to a column of this type, returning an
+// error describing the mismatch if not
+func (c ColumnType) Check(g GISGeometry) error {
+	if g.Geometry == nil {
+		return fmt.Errorf("%v: no geometry provided", c)
+	}
+	if c.GeoType != UNKNOWN && g.GeoType != c.GeoType {
+		return fmt.Errorf("%v: geometry type %v does not match column", c, g.GeoType)
+	}
+	if g.Dimensions != c.dimensions() {
+		return fmt.Errorf("%v: geometry dimensions %v do not match column", c, g.Dimensions)
+	}
+	if c.SRID != 0 && g.SRIDFlag && g.SRID != c.SRID {
+		return fmt.Errorf("%v: geometry srid %v does not match column", c, g.SRID)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestColumnTypeString(t *testing.T) {
+	tests := map[string]geo.ColumnType{
+		"geometry(Point,4326)":    {GeoType: geo.PointType, SRID: 4326},
+		"geometry(Geometry,4326)": {GeoType: geo.UNKNOWN, Dimensions: geo.XY, SRID: 4326},
+		"geometry(LineStringZ)":   {GeoType: geo.LineStringType, Dimensions: geo.XYZ},
+		"geometry(PolygonM,3857)": {GeoType: geo.PolygonType, Dimensions: geo.XYM, SRID: 3857},
+		"geometry(TinZM,4326)":    {GeoType: geo.TINType, Dimensions: geo.XYZM, SRID: 4326},
+	}
+	for expected, column := range tests {
+		if column.String() != expected {
+			t.Errorf("column type %v was not %v", column.String(), expected)
+		}
+	}
+}
+
+func TestColumnTypeCheck(t *testing.T) {
+	pointColumn := geo.ColumnType{GeoType: geo.PointType, Dimensions: geo.XY, SRID: 4326}
+	anyColumn := geo.ColumnType{GeoType: geo.UNKNOWN, Dimensions: geo.XY, SRID: 4326}
+
+	point := geo.NewGISGeometry(makeTestPoint(t, geo.XY))
+	point.SetSRID(4326)
+	if err := pointColumn.Check(point); err != nil {
+		t.Error(err)
+	}
+	if err := anyColumn.Check(point); err != nil {
+		t.Error(err)
+	}
+
+	// Geometry without an SRID takes the column SRID
+	noSRID := geo.NewGISGeometry(makeTestPoint(t, geo.XY))
+	if err := pointColumn.Check(noSRID); err != nil {
+		t.Error(err)
+	}
+
+	otherSRID := geo.NewGISGeometry(makeTestPoint(t, geo.XY))
+	otherSRID.SetSRID(3857)
+	if err := pointColumn.Check(otherSRID); err == nil {
+		t.Errorf("point in srid 3857 was accepted by %v", pointColumn)
+	}
+
+	pointZ := geo.NewGISGeometry(makeTestPoint(t, geo.XYZ))
+	pointZ.SetSRID(4326)
+	if err := pointColumn.Check(pointZ); err == nil {
+		t.Errorf("XYZ point was accepted by %v", pointColumn)
+	}
+
+	multiPoint := geo.NewGISGeometry(makeTestMultiPoint(t, 3, geo.XY))
+	multiPoint.SetSRID(4326)
+	if err := pointColumn.Check(multiPoint); err == nil {
+		t.Errorf("multipoint was accepted by %v", pointColumn)
+	}
+	if err := anyColumn.Check(multiPoint); err != nil {
+		t.Error(err)
+	}
+
+	if err := anyColumn.Check(geo.GISGeometry{}); err == nil {
+		t.Errorf("missing geometry was accepted by %v", anyColumn)
+	}
+}
@@ -38,8 +38,30 @@ func (c CompoundCurve) String() string {
 	return sb.String()
 }
 
-func (*CompoundCurve) Equals(*CompoundCurve) bool {
-	return true
+// Report whether the CompoundCurve equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (c CompoundCurve) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&c, other, o.Tolerance)
+	}
+	d, ok := subtypeAs[CompoundCurve](other)
+	if !ok || c.Dimensions != d.Dimensions {
+		return false
+	}
+	if membersEqual(c.Geometry, d.Geometry, o) {
+		return true
+	}
+
+	// A closed curve may be a ring in the opposite direction
+	first, _, empty := curveEnds(&c)
+	last := lastEnd(c.Geometry)
+	if !o.IgnoreOrientation || empty || !coordsWithin(first, last, o.Tolerance) {
+		return false
+	}
+	reversed := reverseCurve(&c).(*CompoundCurve)
+	o.IgnoreOrientation = false
+	return membersEqual(reversed.Geometry, d.Geometry, o)
 }
 
 // Get the dimensions of the geometry
@@ -47,6 +69,12 @@ func (c CompoundCurve) GetDimensions() Dimensions {
 	return c.Dimensions
 }
 
+// Get the bounding box of the CompoundCurve in each of its dimensions, bounding its
+// arcs rather than their control points
+func (c CompoundCurve) Envelope() Envelope {
+	return membersEnvelope(c.Geometry, c.Dimensions)
+}
+
 // Create a new CompoundCurve
 func NewCompoundCurve() (*CompoundCurve, error) {
 	cc := CompoundCurve{}
@@ -76,39 +104,54 @@ func (c *CompoundCurve) AddCircularString(cs *CircularString) error {
 	return nil
 }
 
-// Create CompoundCurve from input byte buffer in EWKB format and dimensions.
-func CompoundCurveFromEWKB(buffer *bytes.Buffer, dimensions Dimensions) (*CompoundCurve, error) {
+// Create CompoundCurve from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func CompoundCurveFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*CompoundCurve, error) {
+	d, err := newDecodeState(pathName(CompoundCurveType), buffer.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return compoundCurveFromEWKB(buffer, dimensions, d)
+}
+
+func compoundCurveFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, d *decodeState) (*CompoundCurve, error) {
 
 	compoundCurve := CompoundCurve{}
 	compoundCurve.Dimensions = dimensions
-	if buffer.Len() < 4 {
-		return nil, fmt.Errorf("byte array for compoundcurve must be at least length 4")
+	count, err := d.readCount(buffer, "compoundcurve")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.enter(buffer); err != nil {
+		return nil, err
 	}
-	count := binary.LittleEndian.Uint32(buffer.Next(4))
+	defer d.leave()
 
 	for i := 0; i < int(count); i++ {
+		d.at(i)
+		geoType, memberDimensions, err := d.readMemberType(buffer, "compoundcurve", dimensions, LineStringType, CircularStringType)
+		if err != nil {
+			return nil, err
+		}
+		d.push(pathName(geoType))
 
-		//bom := ByteOrder(data[0]) 	// First byte is Byte Order Mark
-		buffer.Next(1)                                                         // move past BOM
-		geoType := GISGeometryType(binary.LittleEndian.Uint16(buffer.Next(4))) // 4 bytes for geometry type
-		//flags := data[3] // flags are stored on the 3rd byte
-
-		var err error
 		var geometry GeometrySubtype
 		switch geoType {
 
 		case LineStringType:
-			geometry, err = LineStringFromEWKB(buffer, dimensions)
+			geometry, err = lineStringFromEWKB(buffer, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 
 		case CircularStringType:
-			geometry, err = CircularStringFromEWKB(buffer, dimensions)
+			geometry, err = circularStringFromEWKB(buffer, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		}
+		forceDimensions(geometry, dimensions)
+		d.pop()
 		compoundCurve.Geometry = append(compoundCurve.Geometry, geometry)
 
 	}
@@ -148,3 +191,59 @@ func (cc CompoundCurve) GetEWKB(includeGeoType bool) bytes.Buffer {
 
 	return *buf
 }
+
+// Report whether the CompoundCurve is valid, as ST_IsValid
+func (c CompoundCurve) IsValid() bool {
+	return c.Validate() == nil
+}
+
+// Check the components of the CompoundCurve are valid LineStrings and
+// CircularStrings, each starting where the previous one ends
+func (c CompoundCurve) Validate() error {
+	return validityError(c.validate(), pathName(CompoundCurveType))
+}
+
+func (c CompoundCurve) validate() *ValidityError {
+	if e := validateMemberTypes(c.Geometry, "CompoundCurve", LineStringType, CircularStringType); e != nil {
+		return e
+	}
+	if e := validateMembers(c.Geometry, c.Dimensions); e != nil {
+		return e
+	}
+	var end []float64
+	for i, component := range c.Geometry {
+		first, last, empty := curveEnds(component)
+		if empty {
+			return invalid("Too few points in geometry component", nil).at(i)
+		}
+		if end != nil && !coordsEqual(end, first) {
+			return invalid("CompoundCurve components are not continuous", first).at(i)
+		}
+		end = last
+	}
+	return nil
+}
+
+// Get the area of the CompoundCurve, which has none
+func (c CompoundCurve) Area() float64 {
+	return 0
+}
+
+// Get the length of the CompoundCurve, as ST_Length
+func (c CompoundCurve) Length() float64 {
+	total := 0.0
+	for _, component := range c.Geometry {
+		total += component.Length()
+	}
+	return total
+}
+
+// Get the perimeter of the CompoundCurve, which has none
+func (c CompoundCurve) Perimeter() float64 {
+	return 0
+}
+
+// Get the minimum distance between the CompoundCurve and another geometry, as ST_Distance
+func (c CompoundCurve) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&c, other)
+}
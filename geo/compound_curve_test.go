@@ -26,6 +26,9 @@ func TestCompoundCurve(t *testing.T) {
 	if !cmp.Equal(compoundCurve, compoundCurve2) {
 		t.Errorf("compoundcurve %v was not equal to compoundcurve %v", compoundCurve, compoundCurve2)
 	}
+	if !compoundCurve.Equals(compoundCurve2) {
+		t.Errorf("compoundcurve %v did not equal compoundcurve %v", compoundCurve, compoundCurve2)
+	}
 
 }
 
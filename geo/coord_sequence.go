@@ -0,0 +1,157 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+)
+
+/*
+Point sequences (LineString, LinearRing, CircularString, MultiPoint and Triangle)
+store their coordinates in a single flat []float64 rather than a slice of Points.
+The ordinates of point i are held at Coords[i*stride : (i+1)*stride], where the
+stride is derived from the Dimensions of the geometry (see Dimensions.Stride).
+*/
+
+// Check that a flat coordinate slice holds a whole number of points for the dimensions
+func checkCoords(coords []float64, dimensions Dimensions) error {
+	stride := dimensions.Stride()
+	if stride == 0 {
+		return fmt.Errorf("unsupported dimensions %v", dimensions)
+	}
+	if len(coords)%stride != 0 {
+		return fmt.Errorf("coordinate length %v is not a multiple of %v for dimensions %v", len(coords), stride, dimensions)
+	}
+	return nil
+}
+
+// Flatten a slice of Points into a single coordinate slice. All points must have the
+// given dimensions and the matching number of ordinates.
+func flattenPoints(p []Point, dimensions Dimensions) ([]float64, error) {
+	stride := dimensions.Stride()
+	coords := make([]float64, 0, len(p)*stride)
+	for i, point := range p {
+		if point.Dimensions != dimensions {
+			return nil, fmt.Errorf("point %v has dimensions %v, expected %v", i, point.Dimensions, dimensions)
+		}
+		if len(point.Coords) != stride {
+			return nil, fmt.Errorf("point %v has %v ordinates, expected %v for dimensions %v", i, len(point.Coords), stride, dimensions)
+		}
+		coords = append(coords, point.Coords...)
+	}
+	return coords, nil
+}
+
+// Get the number of points held in a flat coordinate slice
+func coordCount(coords []float64, dimensions Dimensions) int {
+	stride := dimensions.Stride()
+	if stride == 0 {
+		return 0
+	}
+	return len(coords) / stride
+}
+
+// Get the ordinates of point i. The returned slice shares storage with coords.
+func coordAt(coords []float64, dimensions Dimensions, i int) []float64 {
+	stride := dimensions.Stride()
+	return coords[i*stride : (i+1)*stride : (i+1)*stride]
+}
+
+// Expand a flat coordinate slice into Points. The Points share storage with coords.
+func pointsFromCoords(coords []float64, dimensions Dimensions) []Point {
+	count := coordCount(coords, dimensions)
+	if count == 0 {
+		return nil
+	}
+	points := make([]Point, count)
+	for i := range points {
+		points[i] = Point{Coords: coordAt(coords, dimensions, i), Dimensions: dimensions}
+	}
+	return points
+}
+
+// Report whether two sets of ordinates are identical
+func coordsEqual(a []float64, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Report whether points of dimensions a have every ordinate of dimensions b
+func hasDimensions(a Dimensions, b Dimensions) bool {
+	return a.Stride() > 0 && b.Stride() > 0 && (a.HasZ() || !b.HasZ()) && (a.HasM() || !b.HasM())
+}
+
+// Get a flat coordinate slice with only the ordinates of the target dimensions,
+// which the source dimensions must have. Coordinates already of the target
+// dimensions are returned as they are.
+func projectCoords(coords []float64, from Dimensions, to Dimensions) []float64 {
+	if from == to {
+		return coords
+	}
+	count := coordCount(coords, from)
+	if count == 0 {
+		return nil
+	}
+	projected := make([]float64, 0, count*to.Stride())
+	for i := 0; i < count; i++ {
+		p := coordAt(coords, from, i)
+		projected = append(projected, p[0], p[1])
+		if to.HasZ() {
+			projected = append(projected, p[2])
+		}
+		if to.HasM() {
+			projected = append(projected, p[len(p)-1])
+		}
+	}
+	return projected
+}
+
+// Read count points of the given dimensions from the buffer into a flat coordinate slice
+func readCoords(b *bytes.Buffer, dimensions Dimensions, count uint32) ([]float64, error) {
+	stride := dimensions.Stride()
+	if stride == 0 {
+		return nil, fmt.Errorf("unsupported dimensions %v", dimensions)
+	}
+	length := uint64(count) * uint64(stride)
+	if uint64(b.Len()) < length*8 {
+		return nil, fmt.Errorf("input is too short (%v) for %v points of dimensions %v", b.Len(), count, dimensions)
+	}
+
+	if length == 0 {
+		return nil, nil
+	}
+
+	data := b.Next(int(length * 8))
+	coords := make([]float64, length)
+	for i := range coords {
+		coords[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:]))
+	}
+	return coords, nil
+}
+
+// Write a flat coordinate slice to the buffer as a concatenation of float64 bits
+func writeCoords(buf *bytes.Buffer, coords []float64) {
+	var bits [8]byte
+	for _, c := range coords {
+		binary.LittleEndian.PutUint64(bits[:], math.Float64bits(c))
+		buf.Write(bits[:])
+	}
+}
+
+// Write the Stringer form of each point in a flat coordinate slice
+func writeCoordsString(sb *strings.Builder, coords []float64, dimensions Dimensions) {
+	for i := 0; i < coordCount(coords, dimensions); i++ {
+		p := Point{Coords: coordAt(coords, dimensions, i), Dimensions: dimensions}
+		sb.WriteString(p.String())
+		sb.WriteString(" ")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -28,6 +29,12 @@ func (c CurvePolygon) GetDimensions() Dimensions {
 	return c.Dimensions
 }
 
+// Get the bounding box of the CurvePolygon in each of its dimensions, bounding its
+// arcs rather than their control points
+func (c CurvePolygon) Envelope() Envelope {
+	return membersEnvelope(c.Geometry, c.Dimensions)
+}
+
 // Stringer interface
 func (c CurvePolygon) String() string {
 	var sb strings.Builder
@@ -43,6 +50,17 @@ func (c CurvePolygon) String() string {
 	return sb.String()
 }
 
+// Report whether the CurvePolygon equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (c CurvePolygon) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&c, other, o.Tolerance)
+	}
+	d, ok := subtypeAs[CurvePolygon](other)
+	return ok && c.Dimensions == d.Dimensions && membersEqual(c.Geometry, d.Geometry, o)
+}
+
 // Create a new CurvePolygon
 func NewCurvePolygon() *CurvePolygon {
 	cp := CurvePolygon{}
@@ -83,47 +101,62 @@ func (c *CurvePolygon) AddLineString(ls *LineString) error {
 	return nil
 }
 
-// Create CurvePolygon from input byte buffer in EWKB format and dimensions.
-func CurvePolygonFromEWKB(b *bytes.Buffer, dimensions Dimensions) (*CurvePolygon, error) {
+// Create CurvePolygon from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func CurvePolygonFromEWKB(b *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*CurvePolygon, error) {
+	d, err := newDecodeState(pathName(CurvePolygonType), b.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return curvePolygonFromEWKB(b, dimensions, d)
+}
+
+func curvePolygonFromEWKB(b *bytes.Buffer, dimensions Dimensions, d *decodeState) (*CurvePolygon, error) {
 
 	curvePolygon := CurvePolygon{}
 	curvePolygon.Dimensions = dimensions
-	if b.Len() < 4 {
-		return nil, fmt.Errorf("byte array for curvepolygon must be at least length 4")
+	count, err := d.readCount(b, "curvepolygon")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.enter(b); err != nil {
+		return nil, err
 	}
-	count := binary.LittleEndian.Uint32(b.Next(4))
+	defer d.leave()
 
 	for i := 0; i < int(count); i++ {
+		d.at(i)
+		geoType, memberDimensions, err := d.readMemberType(b, "curvepolygon", dimensions, CircularStringType, CompoundCurveType, LineStringType)
+		if err != nil {
+			return nil, err
+		}
+		d.push(pathName(geoType))
 
-		//bom := ByteOrder(data[0]) 	// First byte is Byte Order Mark
-		b.Next(1)                                                         // move past BOM
-		geoType := GISGeometryType(binary.LittleEndian.Uint16(b.Next(4))) // 4 bytes for geometry type
-		//flags := data[3] // flags are stored on the 3rd byte
-
-		var err error
 		var geometry GeometrySubtype
 
 		switch geoType {
 
 		case CircularStringType:
-			geometry, err = CircularStringFromEWKB(b, dimensions)
+			geometry, err = circularStringFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 
 		case CompoundCurveType:
-			geometry, err = CompoundCurveFromEWKB(b, dimensions)
+			geometry, err = compoundCurveFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 
 		case LineStringType:
-			geometry, err = LineStringFromEWKB(b, dimensions)
+			geometry, err = lineStringFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 
 		}
+		forceDimensions(geometry, dimensions)
+		d.pop()
 		curvePolygon.Geometry = append(curvePolygon.Geometry, geometry)
 
 	}
@@ -168,3 +201,70 @@ func (cp CurvePolygon) GetEWKB(includeGeoType bool) bytes.Buffer {
 
 	return *buf
 }
+
+// Report whether the CurvePolygon is valid, as ST_IsValid
+func (c CurvePolygon) IsValid() bool {
+	return c.Validate() == nil
+}
+
+// Check the rings of the CurvePolygon are valid curves, closed and with
+// enough points to enclose an area
+func (c CurvePolygon) Validate() error {
+	return validityError(c.validate(), pathName(CurvePolygonType))
+}
+
+func (c CurvePolygon) validate() *ValidityError {
+	if e := validateMemberTypes(c.Geometry, "CurvePolygon", LineStringType, CircularStringType, CompoundCurveType); e != nil {
+		return e
+	}
+	if e := validateMembers(c.Geometry, c.Dimensions); e != nil {
+		return e
+	}
+	for i, ring := range c.Geometry {
+		first, last, empty := curveEnds(ring)
+		if empty {
+			return invalid("Too few points in geometry component", nil).at(i)
+		}
+		if !coordsEqual(first, last) {
+			return invalid("Ring is not closed", first).at(i)
+		}
+		if ls, ok := ring.(*LineString); ok && ls.NumPoints() < 4 {
+			return invalid("Too few points in geometry component", first).at(i)
+		}
+	}
+	return nil
+}
+
+// Get the area of the CurvePolygon, that of its shell less that of its holes, as
+// ST_Area, with the area between each arc and its chord measured exactly
+func (c CurvePolygon) Area() float64 {
+	total := 0.0
+	for i, ring := range c.Geometry {
+		area := math.Abs(curveShoelace(ring)) / 2
+		if i == 0 {
+			total += area
+		} else {
+			total -= area
+		}
+	}
+	return total
+}
+
+// Get the length of the CurvePolygon, which has none
+func (c CurvePolygon) Length() float64 {
+	return 0
+}
+
+// Get the perimeter of the CurvePolygon, the length of all its rings, as ST_Perimeter
+func (c CurvePolygon) Perimeter() float64 {
+	total := 0.0
+	for _, ring := range c.Geometry {
+		total += ring.Length()
+	}
+	return total
+}
+
+// Get the minimum distance between the CurvePolygon and another geometry, as ST_Distance
+func (c CurvePolygon) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&c, other)
+}
@@ -0,0 +1,84 @@
+package geo_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestDecodeError(t *testing.T) {
+	// Two polygons of two rings of 5 XY points. Each polygon is a 5 byte
+	// header, a ring count and two rings of a point count and 80 bytes.
+	multiPolygon, err := geo.NewGISGeometry(makeTestMultiPolygon(t, 2)).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondRing := 9 + 177 + 5 + 4 + 4
+
+	// A compoundcurve containing a point
+	compoundCurve := []byte{byte(geo.LittleEndian), byte(geo.CompoundCurveType), 0, 0, 0, 1, 0, 0, 0}
+	compoundCurve = append(compoundCurve, byte(geo.LittleEndian), byte(geo.PointType), 0, 0, 0)
+	compoundCurve = append(compoundCurve, make([]byte, 16)...)
+
+	// A geometrycollection containing a linestring and a member of unknown type
+	collection := []byte{byte(geo.LittleEndian), byte(geo.GeometryCollectionType), 0, 0, 0, 2, 0, 0, 0}
+	collection = append(collection, byte(geo.LittleEndian), byte(geo.LineStringType), 0, 0, 0, 0, 0, 0, 0)
+	collection = append(collection, byte(geo.LittleEndian), 99, 0, 0, 0)
+
+	// A geometrycollection containing a polygon with a ring that is not closed
+	open := []byte{byte(geo.LittleEndian), byte(geo.GeometryCollectionType), 0, 0, 0, 1, 0, 0, 0}
+	open = append(open, byte(geo.LittleEndian), byte(geo.PolygonType), 0, 0, 0, 1, 0, 0, 0, 4, 0, 0, 0)
+	for _, c := range []float64{0, 0, 1, 0, 1, 1, 0, 1} {
+		open = binary.LittleEndian.AppendUint64(open, math.Float64bits(c))
+	}
+
+	tests := []struct {
+		name     string
+		ewkb     []byte
+		opts     geo.DecodeOptions
+		offset   int
+		path     string
+		expected []geo.GISGeometryType
+		actual   geo.GISGeometryType
+		limit    string
+	}{
+		{name: "truncated point", ewkb: multiPolygon[:secondRing+16*2+5],
+			offset: secondRing + 16*2, path: "MultiPolygon[1].ring[0].point[2]"},
+		{name: "wrong member type", ewkb: compoundCurve,
+			offset: 9, path: "CompoundCurve[0]", expected: []geo.GISGeometryType{geo.LineStringType, geo.CircularStringType}, actual: geo.PointType},
+		{name: "unknown member type", ewkb: collection,
+			offset: 18, path: "GeometryCollection[1]", actual: geo.GISGeometryType(99)},
+		{name: "unclosed ring", ewkb: open,
+			offset: 22, path: "GeometryCollection[0].Polygon.ring[0]"},
+		{name: "limit", ewkb: multiPolygon, opts: geo.DecodeOptions{MaxCoords: 12},
+			offset: secondRing, path: "MultiPolygon[1].ring[0]", limit: "MaxCoords"},
+	}
+	for _, test := range tests {
+		var g geo.GISGeometry
+		err := g.DecodeEWKB(test.ewkb, test.opts)
+
+		var decodeErr *geo.DecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Errorf("%v: expected decode error, got %v", test.name, err)
+			continue
+		}
+		if decodeErr.Offset != test.offset {
+			t.Errorf("%v: expected offset %v, got %v", test.name, test.offset, decodeErr.Offset)
+		}
+		if decodeErr.Path != test.path {
+			t.Errorf("%v: expected path %v, got %v", test.name, test.path, decodeErr.Path)
+		}
+		if !cmp.Equal(decodeErr.Expected, test.expected) || decodeErr.Actual != test.actual {
+			t.Errorf("%v: expected %v and %v, got %v and %v", test.name, test.expected, test.actual, decodeErr.Expected, decodeErr.Actual)
+		}
+
+		var limitErr *geo.LimitError
+		if limited := errors.As(err, &limitErr); limited != (test.limit != "") || limited && limitErr.Limit != test.limit {
+			t.Errorf("%v: expected limit %q, got %v", test.name, test.limit, err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package geo
+
+import (
+	"fmt"
+	"strconv"
+)
+
+/*
+Counts in EWKB are read from the input, so untrusted input can request far more
+points, elements or nesting than it holds. DecodeOptions bounds the work done
+and memory used to decode a single geometry. Scan and UnmarshalBinary use
+DefaultDecodeOptions, and the *FromEWKB functions accept DecodeOptions.
+
+The Mode selects how input that PostGIS would not produce is handled, and Warn
+is called with each problem that DecodeLenient repairs or ignores.
+*/
+type DecodeOptions struct {
+	MaxInputSize int // bytes of binary (not hex encoded) EWKB
+	MaxCoords    int // points in the whole geometry
+	MaxElements  int // points, rings or members of any single element
+	MaxDepth     int // nesting of multi geometries and collections
+
+	Mode DecodeMode
+	Warn func(warning *DecodeError)
+}
+
+// DecodeMode selects how EWKB that is malformed but readable is decoded
+type DecodeMode int
+
+const (
+	// Reject input that can not be read as described by its headers, such
+	// as members with different dimensions or SRID to their parent, or
+	// unclosed rings. Trailing bytes are ignored, as are embedded SRIDs of
+	// members other than those of a GeometryCollection, which are kept.
+	DecodeDefault DecodeMode = iota
+
+	// Also reject embedded SRIDs that would be ignored, trailing bytes,
+	// linestrings of one point and rings of fewer than 4 points.
+	DecodeStrict
+
+	// Repair what can be repaired, reporting each repair to Warn: members
+	// with dimensions their parent does not have are reduced to the parent's
+	// dimensions, embedded SRIDs that do not match are dropped, and unclosed
+	// rings and triangles are closed.
+	DecodeLenient
+)
+
+func (m DecodeMode) String() string {
+	switch m {
+	case DecodeDefault:
+		return "DecodeDefault"
+	case DecodeStrict:
+		return "DecodeStrict"
+	case DecodeLenient:
+		return "DecodeLenient"
+	default:
+		return "DecodeMode(" + strconv.Itoa(int(m)) + ")"
+	}
+}
+
+// Options used when none are given. Zero fields of DecodeOptions take their
+// default from here, and negative fields disable the limit.
+var DefaultDecodeOptions = DecodeOptions{
+	MaxInputSize: 64 << 20,
+	MaxCoords:    1 << 24,
+	MaxElements:  1 << 24,
+	MaxDepth:     32,
+}
+
+// Get the options with zero fields replaced by their defaults
+func (o DecodeOptions) withDefaults() DecodeOptions {
+	if o.MaxInputSize == 0 {
+		o.MaxInputSize = DefaultDecodeOptions.MaxInputSize
+	}
+	if o.MaxCoords == 0 {
+		o.MaxCoords = DefaultDecodeOptions.MaxCoords
+	}
+	if o.MaxElements == 0 {
+		o.MaxElements = DefaultDecodeOptions.MaxElements
+	}
+	if o.MaxDepth == 0 {
+		o.MaxDepth = DefaultDecodeOptions.MaxDepth
+	}
+	return o
+}
+
+// LimitError is returned when decoding EWKB would exceed a limit of the DecodeOptions
+type LimitError struct {
+	Limit string // name of the DecodeOptions field
+	Max   int64
+	Value int64
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("ewkb exceeds decode limit %v of %v: %v", e.Limit, e.Max, e.Value)
+}
+
+// Check a value against a limit, where negative limits are disabled
+func checkLimit(limit string, max int, value int64) error {
+	if max >= 0 && value > int64(max) {
+		return &LimitError{Limit: limit, Max: int64(max), Value: value}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package geo_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestDecodeOptions(t *testing.T) {
+	// A linestring claiming 2^30 points in 20 bytes
+	huge := binary.LittleEndian.AppendUint32(nil, 1<<30)
+	huge = append(huge, make([]byte, 16)...)
+
+	// Geometrycollections nested 40 deep
+	var nested []byte
+	for i := 0; i < 40; i++ {
+		nested = append(nested, byte(geo.LittleEndian), byte(geo.GeometryCollectionType), 0, 0, 0, 1, 0, 0, 0)
+	}
+	nested = append(nested, byte(geo.LittleEndian), byte(geo.GeometryCollectionType), 0, 0, 0, 0, 0, 0, 0)
+
+	multiPoint, err := geo.MultiPointFromCoords([]float64{1, 2, 3, 4, 5, 6}, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	points, err := geo.NewGISGeometry(multiPoint).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		decode func() error
+		limit  string
+	}{
+		{"element count", func() error {
+			_, err := geo.LineStringFromEWKB(bytes.NewBuffer(huge), geo.XY)
+			return err
+		}, "MaxElements"},
+		{"element count unlimited", func() error {
+			_, err := geo.LineStringFromEWKB(bytes.NewBuffer(huge), geo.XY, geo.DecodeOptions{MaxElements: -1, MaxCoords: -1})
+			return err
+		}, ""},
+		{"nesting", func() error {
+			var g geo.GISGeometry
+			return g.UnmarshalBinary(nested)
+		}, "MaxDepth"},
+		{"nesting allowed", func() error {
+			var g geo.GISGeometry
+			return g.DecodeEWKB(nested, geo.DecodeOptions{MaxDepth: 50})
+		}, ""},
+		{"view nesting", func() error {
+			v, err := geo.NewEWKBView(nested)
+			if err != nil {
+				return err
+			}
+			_, err = v.NumCoords()
+			return err
+		}, "MaxDepth"},
+		{"view nesting allowed", func() error {
+			v, err := geo.NewEWKBView(nested, geo.DecodeOptions{MaxDepth: 50})
+			if err != nil {
+				return err
+			}
+			_, err = v.NumCoords()
+			return err
+		}, ""},
+		{"coordinates", func() error {
+			var g geo.GISGeometry
+			return g.DecodeEWKB(points, geo.DecodeOptions{MaxCoords: 2})
+		}, "MaxCoords"},
+		{"input size", func() error {
+			var g geo.GISGeometry
+			return g.DecodeEWKB(points, geo.DecodeOptions{MaxInputSize: 10})
+		}, "MaxInputSize"},
+	}
+	for _, test := range tests {
+		err := test.decode()
+		var limitErr *geo.LimitError
+		limited := errors.As(err, &limitErr)
+		if test.limit == "" && limited {
+			t.Errorf("%v: unexpected limit error %v", test.name, err)
+		}
+		if test.limit != "" && (!limited || limitErr.Limit != test.limit) {
+			t.Errorf("%v: expected %v limit error, got %v", test.name, test.limit, err)
+		}
+	}
+}
+
+func TestDecodeModes(t *testing.T) {
+	const (
+		z    = 0x80 << 24
+		srid = 0x20 << 24
+	)
+	le := byte(geo.LittleEndian)
+
+	tests := []struct {
+		name     string
+		ewkb     []byte
+		defaults bool   // decodes with DecodeDefault
+		strict   bool   // decodes with DecodeStrict
+		lenient  string // WKT decoded with DecodeLenient, or empty if it fails
+		warnings int    // warnings from DecodeLenient
+	}{
+		{"member with extra dimensions",
+			testEWKB(le, uint32(geo.GeometryCollectionType), uint32(1), le, uint32(geo.PointType)|z, 1.0, 2.0, 3.0),
+			false, false, "GEOMETRYCOLLECTION(POINT(1 2))", 1},
+		{"multipoint member with extra dimensions",
+			testEWKB(le, uint32(geo.MultiPointType), uint32(2), le, uint32(geo.PointType), 1.0, 2.0, le, uint32(geo.PointType)|z, 3.0, 4.0, 5.0),
+			false, false, "MULTIPOINT(1 2,3 4)", 1},
+		{"member with missing dimensions",
+			testEWKB(le, uint32(geo.MultiPointType)|z, uint32(1), le, uint32(geo.PointType), 1.0, 2.0),
+			false, false, "", 0},
+		{"member with srid",
+			testEWKB(le, uint32(geo.MultiPointType), uint32(1), le, uint32(geo.PointType)|srid, uint32(4326), 1.0, 2.0),
+			true, false, "MULTIPOINT(1 2)", 1},
+		{"unclosed ring",
+			testEWKB(le, uint32(geo.PolygonType), uint32(1), uint32(3), 0.0, 0.0, 1.0, 0.0, 1.0, 1.0),
+			false, false, "POLYGON((0 0,1 0,1 1,0 0))", 1},
+		{"ring of 3 points",
+			testEWKB(le, uint32(geo.PolygonType), uint32(1), uint32(3), 0.0, 0.0, 1.0, 1.0, 0.0, 0.0),
+			true, false, "POLYGON((0 0,1 1,0 0))", 0},
+		{"triangle of 3 points",
+			testEWKB(le, uint32(geo.TriangleType), uint32(1), uint32(3), 0.0, 0.0, 1.0, 0.0, 1.0, 1.0),
+			false, false, "TRIANGLE((0 0,1 0,1 1,0 0))", 1},
+		{"linestring of 1 point",
+			testEWKB(le, uint32(geo.LineStringType), uint32(1), 1.0, 2.0),
+			true, false, "LINESTRING(1 2)", 0},
+		{"collection member with srid",
+			testEWKB(le, uint32(geo.GeometryCollectionType)|srid, uint32(4326), uint32(1), le, uint32(geo.PointType)|srid, uint32(4326), 1.0, 2.0),
+			true, true, "GEOMETRYCOLLECTION(POINT(1 2))", 0},
+		{"collection member with different srid",
+			testEWKB(le, uint32(geo.GeometryCollectionType)|srid, uint32(4326), uint32(1), le, uint32(geo.PointType)|srid, uint32(3857), 1.0, 2.0),
+			false, false, "GEOMETRYCOLLECTION(POINT(1 2))", 1},
+		{"collection members with different srids",
+			testEWKB(le, uint32(geo.GeometryCollectionType), uint32(2), le, uint32(geo.PointType)|srid, uint32(4326), 1.0, 2.0, le, uint32(geo.PointType)|srid, uint32(3857), 1.0, 2.0),
+			false, false, "GEOMETRYCOLLECTION(POINT(1 2),POINT(1 2))", 1},
+		{"trailing bytes",
+			testEWKB(le, uint32(geo.PointType), 1.0, 2.0, byte(0)),
+			true, false, "POINT(1 2)", 1},
+		{"big endian member",
+			testEWKB(le, uint32(geo.MultiPointType), uint32(1), byte(0), uint32(geo.PointType), 1.0, 2.0),
+			false, false, "", 0},
+	}
+	for _, test := range tests {
+		var g geo.GISGeometry
+		if err := g.DecodeEWKB(test.ewkb, geo.DecodeOptions{}); (err == nil) != test.defaults {
+			t.Errorf("%v: unexpected DecodeDefault result %v", test.name, err)
+		}
+		if err := g.DecodeEWKB(test.ewkb, geo.DecodeOptions{Mode: geo.DecodeStrict}); (err == nil) != test.strict {
+			t.Errorf("%v: unexpected DecodeStrict result %v", test.name, err)
+		}
+
+		var warnings []*geo.DecodeError
+		err := g.DecodeEWKB(test.ewkb, geo.DecodeOptions{
+			Mode: geo.DecodeLenient,
+			Warn: func(w *geo.DecodeError) { warnings = append(warnings, w) },
+		})
+		if test.lenient == "" {
+			if err == nil {
+				t.Errorf("%v: expected DecodeLenient error, got %v", test.name, g.WKT())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected DecodeLenient error %v", test.name, err)
+			continue
+		}
+		if g.WKT() != test.lenient {
+			t.Errorf("%v: expected %v, got %v", test.name, test.lenient, g.WKT())
+		}
+		if len(warnings) != test.warnings {
+			t.Errorf("%v: expected %v warnings, got %v", test.name, test.warnings, warnings)
+		}
+	}
+}
+
+// Build EWKB from bytes, uint32s and float64s
+func testEWKB(values ...interface{}) []byte {
+	var b []byte
+	for _, v := range values {
+		switch v := v.(type) {
+		case byte:
+			b = append(b, v)
+		case uint32:
+			b = binary.LittleEndian.AppendUint32(b, v)
+		case float64:
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+		}
+	}
+	return b
+}
@@ -0,0 +1,294 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+/*
+	https://postgis.net/docs/ST_Envelope.html
+	https://postgis.net/docs/ST_3DExtent.html
+
+An Envelope is the bounding box of a geometry in the ordinates it has, so Z and
+M are bounded when its Dimensions have them. The envelope of a curve bounds its
+arcs rather than only their control points, and Z and M along an arc lie
+between those of its points. Empty points are not bounded, and an Envelope
+bounding nothing is empty, with minimums greater than maximums.
+
+Operations on two envelopes use the ordinates both have.
+*/
+type Envelope struct {
+	MinX       float64
+	MinY       float64
+	MinZ       float64
+	MinM       float64
+	MaxX       float64
+	MaxY       float64
+	MaxZ       float64
+	MaxM       float64
+	Dimensions Dimensions
+}
+
+// Create an empty Envelope of the given dimensions
+func NewEnvelope(dimensions Dimensions) Envelope {
+	inf := math.Inf(1)
+	return Envelope{
+		MinX: inf, MinY: inf, MinZ: inf, MinM: inf,
+		MaxX: -inf, MaxY: -inf, MaxZ: -inf, MaxM: -inf,
+		Dimensions: dimensions,
+	}
+}
+
+// Stringer interface
+func (e Envelope) String() string {
+	if e.IsEmpty() {
+		return "(Envelope " + e.Dimensions.String() + " EMPTY)"
+	}
+	var sb strings.Builder
+	sb.WriteString("(Envelope ")
+	sb.WriteString(e.Dimensions.String())
+	sb.WriteString(" [")
+	sb.WriteString(formatBoxCoords(e.ordinates(e.MinX, e.MinY, e.MinZ, e.MinM)...))
+	sb.WriteString(",")
+	sb.WriteString(formatBoxCoords(e.ordinates(e.MaxX, e.MaxY, e.MaxZ, e.MaxM)...))
+	sb.WriteString("])")
+	return sb.String()
+}
+
+// Get those of the given ordinates the envelope has
+func (e Envelope) ordinates(x, y, z, m float64) []float64 {
+	ordinates := []float64{x, y}
+	if e.Dimensions.HasZ() {
+		ordinates = append(ordinates, z)
+	}
+	if e.Dimensions.HasM() {
+		ordinates = append(ordinates, m)
+	}
+	return ordinates
+}
+
+// Report whether the envelope bounds nothing
+func (e Envelope) IsEmpty() bool {
+	return !(e.MinX <= e.MaxX && e.MinY <= e.MaxY)
+}
+
+// Get the dimensions both envelopes have
+func commonDimensions(a Dimensions, b Dimensions) Dimensions {
+	switch z, m := a.HasZ() && b.HasZ(), a.HasM() && b.HasM(); {
+	case z && m:
+		return XYZM
+	case z:
+		return XYZ
+	case m:
+		return XYM
+	default:
+		return XY
+	}
+}
+
+// Report whether the envelopes share any point
+func (e Envelope) Intersects(other Envelope) bool {
+	return !e.Intersection(other).IsEmpty()
+}
+
+// Get the envelope of the points in both envelopes, which is empty if they do
+// not intersect
+func (e Envelope) Intersection(other Envelope) Envelope {
+	dimensions := commonDimensions(e.Dimensions, other.Dimensions)
+	if e.IsEmpty() || other.IsEmpty() {
+		return NewEnvelope(dimensions)
+	}
+	i := Envelope{
+		MinX: math.Max(e.MinX, other.MinX), MinY: math.Max(e.MinY, other.MinY),
+		MinZ: math.Max(e.MinZ, other.MinZ), MinM: math.Max(e.MinM, other.MinM),
+		MaxX: math.Min(e.MaxX, other.MaxX), MaxY: math.Min(e.MaxY, other.MaxY),
+		MaxZ: math.Min(e.MaxZ, other.MaxZ), MaxM: math.Min(e.MaxM, other.MaxM),
+		Dimensions: dimensions,
+	}
+	if i.MinX > i.MaxX || i.MinY > i.MaxY ||
+		dimensions.HasZ() && i.MinZ > i.MaxZ || dimensions.HasM() && i.MinM > i.MaxM {
+		return NewEnvelope(dimensions)
+	}
+	return i.clear()
+}
+
+// Report whether every point of the other envelope is in the envelope
+func (e Envelope) Contains(other Envelope) bool {
+	if e.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+	dimensions := commonDimensions(e.Dimensions, other.Dimensions)
+	return e.MinX <= other.MinX && other.MaxX <= e.MaxX &&
+		e.MinY <= other.MinY && other.MaxY <= e.MaxY &&
+		(!dimensions.HasZ() || e.MinZ <= other.MinZ && other.MaxZ <= e.MaxZ) &&
+		(!dimensions.HasM() || e.MinM <= other.MinM && other.MaxM <= e.MaxM)
+}
+
+// Report whether the ordinates of a point are in the envelope, as many of X,
+// Y, Z and M as the dimensions of the point and envelope share
+func (e Envelope) ContainsPoint(coords []float64, dimensions Dimensions) bool {
+	if len(coords) != dimensions.Stride() || pointEmpty(coords) {
+		return false
+	}
+	p := NewEnvelope(dimensions)
+	p.addCoords(coords)
+	return e.Contains(p)
+}
+
+// Get the envelope of the points of both envelopes
+func (e Envelope) Union(other Envelope) Envelope {
+	u := NewEnvelope(commonDimensions(e.Dimensions, other.Dimensions))
+	u.add(e)
+	u.add(other)
+	return u
+}
+
+// Get the envelope grown by a distance in each direction of X and Y, and of
+// Z if it has Z, as ST_Expand. A negative distance shrinks the envelope,
+// leaving it empty if it shrinks past its center.
+func (e Envelope) Expand(distance float64) Envelope {
+	if e.IsEmpty() {
+		return e
+	}
+	x := e
+	x.MinX, x.MinY, x.MaxX, x.MaxY = e.MinX-distance, e.MinY-distance, e.MaxX+distance, e.MaxY+distance
+	if e.Dimensions.HasZ() {
+		x.MinZ, x.MaxZ = e.MinZ-distance, e.MaxZ+distance
+	}
+	if x.MinX > x.MaxX || x.MinY > x.MaxY || x.Dimensions.HasZ() && x.MinZ > x.MaxZ {
+		return NewEnvelope(e.Dimensions)
+	}
+	return x
+}
+
+// Get the XY extent of the envelope as a Box2D
+func (e Envelope) Box2D() (*Box2D, error) {
+	if e.IsEmpty() {
+		return nil, fmt.Errorf("envelope is empty")
+	}
+	return &Box2D{MinX: e.MinX, MinY: e.MinY, MaxX: e.MaxX, MaxY: e.MaxY}, nil
+}
+
+// Get the XYZ extent of the envelope as a Box3D, with a Z range of 0 if it
+// has no Z as ST_3DExtent does
+func (e Envelope) Box3D() (*Box3D, error) {
+	if e.IsEmpty() {
+		return nil, fmt.Errorf("envelope is empty")
+	}
+	b := Box3D{MinX: e.MinX, MinY: e.MinY, MaxX: e.MaxX, MaxY: e.MaxY}
+	if e.Dimensions.HasZ() {
+		b.MinZ, b.MaxZ = e.MinZ, e.MaxZ
+	}
+	return &b, nil
+}
+
+// Get the XY extent of the envelope as a Polygon, with the single ring ordered
+// (minx miny, minx maxy, maxx maxy, maxx miny, minx miny) as ST_Envelope does
+func (e Envelope) ToPolygon() (*Polygon, error) {
+	b, err := e.Box2D()
+	if err != nil {
+		return nil, err
+	}
+	return b.Polygon()
+}
+
+// Set the ordinates the envelope does not have to those of an empty envelope,
+// so envelopes of equal extent are equal
+func (e Envelope) clear() Envelope {
+	empty := NewEnvelope(e.Dimensions)
+	if !e.Dimensions.HasZ() {
+		e.MinZ, e.MaxZ = empty.MinZ, empty.MaxZ
+	}
+	if !e.Dimensions.HasM() {
+		e.MinM, e.MaxM = empty.MinM, empty.MaxM
+	}
+	return e
+}
+
+// Extend the envelope to include another, in the ordinates the envelope has
+func (e *Envelope) add(other Envelope) {
+	if other.IsEmpty() {
+		return
+	}
+	e.MinX, e.MaxX = math.Min(e.MinX, other.MinX), math.Max(e.MaxX, other.MaxX)
+	e.MinY, e.MaxY = math.Min(e.MinY, other.MinY), math.Max(e.MaxY, other.MaxY)
+	if e.Dimensions.HasZ() && other.Dimensions.HasZ() {
+		e.MinZ, e.MaxZ = math.Min(e.MinZ, other.MinZ), math.Max(e.MaxZ, other.MaxZ)
+	}
+	if e.Dimensions.HasM() && other.Dimensions.HasM() {
+		e.MinM, e.MaxM = math.Min(e.MinM, other.MinM), math.Max(e.MaxM, other.MaxM)
+	}
+}
+
+// Extend the envelope to include the points of a flat coordinate slice of
+// its dimensions, other than empty points
+func (e *Envelope) addCoords(coords []float64) {
+	stride := e.Dimensions.Stride()
+	if stride == 0 {
+		return
+	}
+	for i := 0; i+stride <= len(coords); i += stride {
+		p := coords[i : i+stride]
+		if pointEmpty(p) {
+			continue
+		}
+		e.MinX, e.MaxX = math.Min(e.MinX, p[0]), math.Max(e.MaxX, p[0])
+		e.MinY, e.MaxY = math.Min(e.MinY, p[1]), math.Max(e.MaxY, p[1])
+		if e.Dimensions.HasZ() {
+			e.MinZ, e.MaxZ = math.Min(e.MinZ, p[2]), math.Max(e.MaxZ, p[2])
+		}
+		if e.Dimensions.HasM() {
+			e.MinM, e.MaxM = math.Min(e.MinM, p[stride-1]), math.Max(e.MaxM, p[stride-1])
+		}
+	}
+}
+
+// Extend the envelope to include the arcs of a CircularString, with the
+// points of the circle at each quarter turn where the arcs pass them
+func (e *Envelope) addArcs(coords []float64) {
+	e.addCoords(coords)
+	n := coordCount(coords, e.Dimensions)
+	for i := 2; i < n; i += 2 {
+		a, ok := arcThrough(coordAt(coords, e.Dimensions, i-2), coordAt(coords, e.Dimensions, i-1), coordAt(coords, e.Dimensions, i))
+		if !ok {
+			continue
+		}
+		for quarter := 0; quarter < 4; quarter++ {
+			angle := float64(quarter) * math.Pi / 2
+			from := normalizeAngle(angle - a.start)
+			if a.sweep < 0 {
+				from = normalizeAngle(a.start - angle)
+			}
+			if from <= math.Abs(a.sweep) {
+				x, y := a.at(angle - a.start)
+				e.MinX, e.MaxX = math.Min(e.MinX, x), math.Max(e.MaxX, x)
+				e.MinY, e.MaxY = math.Min(e.MinY, y), math.Max(e.MaxY, y)
+			}
+		}
+	}
+}
+
+// Get the envelope of a flat coordinate slice
+func coordsEnvelope(coords []float64, dimensions Dimensions) Envelope {
+	e := NewEnvelope(dimensions)
+	e.addCoords(coords)
+	return e.clear()
+}
+
+// Get the envelope of the members of a geometry
+func membersEnvelope(members []GeometrySubtype, dimensions Dimensions) Envelope {
+	e := NewEnvelope(dimensions)
+	for _, member := range members {
+		e.add(member.Envelope())
+	}
+	return e.clear()
+}
+
+// Get the envelope of the geometry, or an empty XY envelope if there is none
+func (g GISGeometry) Envelope() Envelope {
+	if g.Geometry == nil {
+		return NewEnvelope(XY)
+	}
+	return g.Geometry.Envelope()
+}
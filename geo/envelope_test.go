@@ -0,0 +1,120 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestEnvelope(t *testing.T) {
+	envelope := func(dimensions geo.Dimensions, min []float64, max []float64) geo.Envelope {
+		e := geo.NewEnvelope(dimensions)
+		e.MinX, e.MinY, e.MaxX, e.MaxY = min[0], min[1], max[0], max[1]
+		if dimensions.HasZ() {
+			e.MinZ, e.MaxZ = min[2], max[2]
+		}
+		if dimensions.HasM() {
+			e.MinM, e.MaxM = min[len(min)-1], max[len(max)-1]
+		}
+		return e
+	}
+	s := math.Sqrt2 / 2
+	multiPolygon := makeTestMultiPolygon(t, 2)
+
+	tests := []struct {
+		name     string
+		geometry geo.GeometrySubtype
+		envelope geo.Envelope
+	}{
+		{"point", &geo.Point{Coords: []float64{1, 2}, Dimensions: geo.XY},
+			envelope(geo.XY, []float64{1, 2}, []float64{1, 2})},
+		{"empty point", &geo.Point{Coords: []float64{math.NaN(), math.NaN()}, Dimensions: geo.XY},
+			geo.NewEnvelope(geo.XY)},
+		{"linestring XYZ", &geo.LineString{Coords: []float64{0, 5, -1, 3, 1, 2}, Dimensions: geo.XYZ},
+			envelope(geo.XYZ, []float64{0, 1, -1}, []float64{3, 5, 2})},
+		{"linestring XYM", &geo.LineString{Coords: []float64{0, 5, 7, 3, 1, 4}, Dimensions: geo.XYM},
+			envelope(geo.XYM, []float64{0, 1, 4}, []float64{3, 5, 7})},
+		{"multipoint XYZM", &geo.MultiPoint{Coords: []float64{0, 0, 1, 2, 4, 4, 3, 1}, Dimensions: geo.XYZM},
+			envelope(geo.XYZM, []float64{0, 0, 1, 1}, []float64{4, 4, 3, 2})},
+		{"arc passing the top of its circle", xyCircularString(0, 0, 1-s, s, 1+s, s),
+			envelope(geo.XY, []float64{0, 0}, []float64{1 + s, 1})},
+		{"arc the other way", xyCircularString(1+s, s, 1-s, s, 0, 0),
+			envelope(geo.XY, []float64{0, 0}, []float64{1 + s, 1})},
+		{"arc below its chord", xyCircularString(0, 0, 1, -1, 2, 0),
+			envelope(geo.XY, []float64{0, -1}, []float64{2, 0})},
+		{"full circle", xyCircularString(0, 0, 2, 0, 0, 0),
+			envelope(geo.XY, []float64{0, -1}, []float64{2, 1})},
+		{"curvepolygon", &geo.CurvePolygon{Geometry: []geo.GeometrySubtype{&geo.CompoundCurve{Geometry: []geo.GeometrySubtype{
+			xyCircularString(0, 0, 1, 1, 2, 0), &geo.LineString{Coords: []float64{2, 0, 0, 0}, Dimensions: geo.XY}}, Dimensions: geo.XY}}, Dimensions: geo.XY},
+			envelope(geo.XY, []float64{0, 0}, []float64{2, 1})},
+		{"multipolygon", multiPolygon, multiPolygon.Polygons[0].Envelope().Union(multiPolygon.Polygons[1].Envelope())},
+		{"collection", &geo.GeometryCollection{Geometry: []geo.GeometrySubtype{
+			&geo.Point{Coords: []float64{-1, 3}, Dimensions: geo.XY}, xyCircularString(0, 0, 1, 1, 2, 0)}, Dimensions: geo.XY},
+			envelope(geo.XY, []float64{-1, 0}, []float64{2, 3})},
+	}
+	approx := cmp.Comparer(func(a, b float64) bool { return a == b || math.Abs(a-b) < 1e-12 })
+	for _, test := range tests {
+		if e := test.geometry.Envelope(); !cmp.Equal(e, test.envelope, approx) {
+			t.Errorf("%v: expected envelope %v, got %v", test.name, test.envelope, e)
+		}
+	}
+}
+
+func TestEnvelopeOperations(t *testing.T) {
+	a := geo.LineString{Coords: []float64{0, 0, 0, 4, 4, 2}, Dimensions: geo.XYZ}
+	b := geo.LineString{Coords: []float64{2, 2, 1, 6, 6, 5}, Dimensions: geo.XYZ}
+	c := geo.LineString{Coords: []float64{5, 5, 6, 6}, Dimensions: geo.XY}
+	ea, eb, ec := a.Envelope(), b.Envelope(), c.Envelope()
+
+	if !ea.Intersects(eb) || ea.Intersects(ec) || !eb.Intersects(ec) {
+		t.Errorf("unexpected intersections of %v, %v and %v", ea, eb, ec)
+	}
+	above := geo.LineString{Coords: []float64{1, 1, 3, 2, 2, 4}, Dimensions: geo.XYZ}
+	if ea.Intersects(above.Envelope()) {
+		t.Errorf("%v intersected %v above it", ea, above.Envelope())
+	}
+
+	i := ea.Intersection(eb)
+	if i.MinX != 2 || i.MinY != 2 || i.MinZ != 1 || i.MaxX != 4 || i.MaxY != 4 || i.MaxZ != 2 {
+		t.Errorf("unexpected intersection %v", i)
+	}
+	if !ea.Intersection(ec).IsEmpty() {
+		t.Errorf("expected empty intersection of %v and %v", ea, ec)
+	}
+
+	u := ea.Union(eb)
+	if !u.Contains(ea) || !u.Contains(eb) || u.MinZ != 0 || u.MaxZ != 5 {
+		t.Errorf("unexpected union %v", u)
+	}
+	if ea.Contains(eb) || ea.Contains(geo.NewEnvelope(geo.XY)) {
+		t.Errorf("%v contained %v", ea, eb)
+	}
+	if u := ea.Union(ec); u.Dimensions != geo.XY || u.MaxX != 6 {
+		t.Errorf("unexpected union of XYZ and XY envelopes %v", u)
+	}
+	if !ea.ContainsPoint([]float64{1, 1}, geo.XY) || ea.ContainsPoint([]float64{1, 1, 3}, geo.XYZ) {
+		t.Errorf("unexpected point containment in %v", ea)
+	}
+
+	x := ea.Expand(1)
+	if x.MinX != -1 || x.MaxY != 5 || x.MinZ != -1 || x.MaxZ != 3 {
+		t.Errorf("unexpected expanded envelope %v", x)
+	}
+	if !ea.Expand(-3).IsEmpty() {
+		t.Errorf("expected empty envelope shrinking %v", ea)
+	}
+
+	p, err := ea.ToPolygon()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{0, 0, 0, 4, 4, 4, 4, 0, 0, 0}
+	if !cmp.Equal(p.LinearRings[0].Coords, expected) {
+		t.Errorf("expected polygon ring %v, got %v", expected, p.LinearRings[0].Coords)
+	}
+	if _, err := geo.NewEnvelope(geo.XY).ToPolygon(); err == nil {
+		t.Error("expected error getting polygon of empty envelope")
+	}
+}
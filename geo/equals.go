@@ -0,0 +1,474 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+	https://postgis.net/docs/ST_OrderingEquals.html
+	https://postgis.net/docs/ST_Equals.html
+
+Equals compares geometries as ST_OrderingEquals does: they are the same type,
+with the same dimensions and members, and every ordinate is identical. Empty
+points are equal to each other. EqualOptions loosen the comparison:
+
+  - Tolerance allows each ordinate to differ by up to the given amount
+  - IgnoreRingStart and IgnoreOrientation compare closed point sequences, such
+    as the rings of a polygon, as rings, equal from any starting point or in
+    either direction. The arcs of a closed CircularString must start at the
+    same points, and CompoundCurve rings can only be reversed.
+  - IgnoreSRID ignores the SRIDs of GISGeometry and GeometryCollection members
+  - Topological compares the points covered in the XY plane, as ST_Equals, so
+    a LineString equals a MultiLineString of its segments and polygons equal
+    those with the same area regardless of ring start, direction or how they
+    are divided. Curves are linearized with DefaultSegmentsPerQuarter, and
+    points within Tolerance of each other are the same point.
+*/
+type EqualOptions struct {
+	Tolerance         float64
+	IgnoreRingStart   bool
+	IgnoreOrientation bool
+	IgnoreSRID        bool
+	Topological       bool
+}
+
+// Get the EqualOptions given to Equals, or the zero value for exact equality
+func equalOptions(opts []EqualOptions) EqualOptions {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return EqualOptions{}
+}
+
+// Get a geometry as the type T, whether it holds a T or a *T
+func subtypeAs[T any](g GeometrySubtype) (T, bool) {
+	switch t := any(g).(type) {
+	case T:
+		return t, true
+	case *T:
+		if t != nil {
+			return *t, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Report whether each ordinate of a is within tolerance of that of b, with
+// NaN ordinates of empty points equal
+func coordsWithin(a []float64, b []float64, tolerance float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] && !(math.IsNaN(a[i]) && math.IsNaN(b[i])) && !(math.Abs(a[i]-b[i]) <= tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// Report whether two point sequences are equal, comparing closed sequences as
+// rings if the options allow. Rings may only start at every step-th point.
+func sequencesEqual(a []float64, b []float64, dimensions Dimensions, step int, o EqualOptions) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	if coordsWithin(a, b, o.Tolerance) {
+		return true
+	}
+	n := coordCount(a, dimensions)
+	if !(o.IgnoreRingStart || o.IgnoreOrientation) || n < 2 ||
+		!coordsWithin(coordAt(a, dimensions, 0), coordAt(a, dimensions, n-1), o.Tolerance) ||
+		!coordsWithin(coordAt(b, dimensions, 0), coordAt(b, dimensions, n-1), o.Tolerance) {
+		return false
+	}
+
+	// Compare the distinct points of the rings from each start and direction
+	m := n - 1
+	matches := func(index func(i int) int) bool {
+		for i := 0; i < m; i++ {
+			if !coordsWithin(coordAt(a, dimensions, i), coordAt(b, dimensions, index(i)), o.Tolerance) {
+				return false
+			}
+		}
+		return true
+	}
+	for k := 0; k < m; k += step {
+		if k > 0 && !o.IgnoreRingStart {
+			break
+		}
+		if k > 0 && matches(func(i int) int { return (i + k) % m }) {
+			return true
+		}
+		if o.IgnoreOrientation && matches(func(i int) int { return (k - i + m) % m }) {
+			return true
+		}
+	}
+	return false
+}
+
+// Report whether the members of two geometries are equal in order
+func membersEqual(a []GeometrySubtype, b []GeometrySubtype, o EqualOptions) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equals(b[i], o) {
+			return false
+		}
+	}
+	return true
+}
+
+// Get a curve in the opposite direction
+func reverseCurve(g GeometrySubtype) GeometrySubtype {
+	switch t := g.(type) {
+	case *LineString:
+		return &LineString{Coords: reverseCoords(t.Coords, t.Dimensions), Dimensions: t.Dimensions}
+	case *CircularString:
+		return &CircularString{Coords: reverseCoords(t.Coords, t.Dimensions), Dimensions: t.Dimensions}
+	case *CompoundCurve:
+		c := CompoundCurve{Dimensions: t.Dimensions}
+		for i := len(t.Geometry) - 1; i >= 0; i-- {
+			c.Geometry = append(c.Geometry, reverseCurve(t.Geometry[i]))
+		}
+		return &c
+	}
+	return g
+}
+
+// Get a flat coordinate slice with the points in reverse order
+func reverseCoords(coords []float64, dimensions Dimensions) []float64 {
+	reversed := make([]float64, 0, len(coords))
+	for i := coordCount(coords, dimensions) - 1; i >= 0; i-- {
+		reversed = append(reversed, coordAt(coords, dimensions, i)...)
+	}
+	return reversed
+}
+
+// The points covered by a geometry in the XY plane, as isolated points, line
+// segments and the rings of polygons
+type pointSet struct {
+	points   [][2]float64
+	lines    [][2][2]float64
+	polygons [][][][2]float64
+}
+
+// Add the points covered by a geometry to the set
+func (s *pointSet) add(g GeometrySubtype) error {
+	switch t := g.(type) {
+	case *Point:
+		return s.addPoints(t.Coords, t.Dimensions)
+	case *MultiPoint:
+		return s.addPoints(t.Coords, t.Dimensions)
+	case *LineString:
+		return s.addLine(t.Coords, t.Dimensions)
+	case *LinearRing:
+		return s.addLine(t.Coords, t.Dimensions)
+	case *CircularString, *CompoundCurve:
+		coords, err := linearizeCurve(t, DefaultSegmentsPerQuarter)
+		if err != nil {
+			return err
+		}
+		return s.addLine(coords, t.GetDimensions())
+	case *MultiLineString:
+		for i := range t.LineStrings {
+			if err := s.add(&t.LineStrings[i]); err != nil {
+				return err
+			}
+		}
+	case *Polygon:
+		var rings [][]float64
+		for _, ring := range t.LinearRings {
+			rings = append(rings, ring.Coords)
+		}
+		return s.addPolygon(rings, t.Dimensions)
+	case *Triangle:
+		return s.addPolygon([][]float64{t.Coords}, t.Dimensions)
+	case *CurvePolygon:
+		p, err := t.Linearize(DefaultSegmentsPerQuarter)
+		if err != nil {
+			return err
+		}
+		return s.add(p)
+	case *MultiPolygon:
+		for i := range t.Polygons {
+			if err := s.add(&t.Polygons[i]); err != nil {
+				return err
+			}
+		}
+	case *PolyHedralSurface:
+		for i := range t.Polygons {
+			if err := s.add(&t.Polygons[i]); err != nil {
+				return err
+			}
+		}
+	case *TIN:
+		for i := range t.Triangles {
+			if err := s.add(&t.Triangles[i]); err != nil {
+				return err
+			}
+		}
+	case *MultiCurve:
+		return s.addMembers(t.Geometry)
+	case *MultiSurface:
+		return s.addMembers(t.Geometry)
+	case *GeometryCollection:
+		return s.addMembers(t.Geometry)
+	default:
+		return fmt.Errorf("unsupported geometry %T", g)
+	}
+	return nil
+}
+
+// Add the points covered by each member of a geometry to the set
+func (s *pointSet) addMembers(members []GeometrySubtype) error {
+	for _, member := range members {
+		if err := s.add(member); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add the points of a point sequence, other than empty points, to the set
+func (s *pointSet) addPoints(coords []float64, dimensions Dimensions) error {
+	if err := checkCoords(coords, dimensions); err != nil {
+		return err
+	}
+	for i := 0; i < coordCount(coords, dimensions); i++ {
+		p := coordAt(coords, dimensions, i)
+		if pointEmpty(p) {
+			continue
+		}
+		if e := validateCoords(p, dimensions, false); e != nil {
+			return e
+		}
+		s.points = append(s.points, xy(p))
+	}
+	return nil
+}
+
+// Add the segments of a line to the set, or a point if all its points are the same
+func (s *pointSet) addLine(coords []float64, dimensions Dimensions) error {
+	if e := validateCoords(coords, dimensions, false); e != nil {
+		return e
+	}
+	n := coordCount(coords, dimensions)
+	for i := 1; i < n; i++ {
+		a, b := xy(coordAt(coords, dimensions, i-1)), xy(coordAt(coords, dimensions, i))
+		if a != b {
+			s.lines = append(s.lines, [2][2]float64{a, b})
+		}
+	}
+	if len(distinctXY(coords, dimensions)) == 1 {
+		s.points = append(s.points, xy(coordAt(coords, dimensions, 0)))
+	}
+	return nil
+}
+
+// Add the rings of a polygon enclosing any area to the set
+func (s *pointSet) addPolygon(rings [][]float64, dimensions Dimensions) error {
+	var polygon [][][2]float64
+	for _, coords := range rings {
+		if e := validateCoords(coords, dimensions, false); e != nil {
+			return e
+		}
+		var ring [][2]float64
+		for _, p := range cleanRing(coords, dimensions) {
+			ring = append(ring, xy(p))
+		}
+		if ring != nil {
+			polygon = append(polygon, ring)
+		}
+	}
+	if polygon != nil {
+		s.polygons = append(s.polygons, polygon)
+	}
+	return nil
+}
+
+// Locate a point relative to a polygon of the set, where holes are the
+// rings it is inside an even number of
+func locateInPolygon(p [2]float64, polygon [][][2]float64) ringLocation {
+	in := false
+	for _, ring := range polygon {
+		switch locateInRing(p, ring) {
+		case onBoundary:
+			return onBoundary
+		case inside:
+			in = !in
+		}
+	}
+	if in {
+		return inside
+	}
+	return outside
+}
+
+// Get the distance from a point to a segment
+func distanceToSegment(p [2]float64, a [2]float64, b [2]float64) float64 {
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	t := 0.0
+	if l := dx*dx + dy*dy; l > 0 {
+		t = math.Max(0, math.Min(1, ((p[0]-a[0])*dx+(p[1]-a[1])*dy)/l))
+	}
+	return math.Hypot(p[0]-a[0]-t*dx, p[1]-a[1]-t*dy)
+}
+
+// Report whether two geometries cover the same points in the XY plane, as
+// ST_Equals. The segments of both are split where they meet, and each piece
+// compared by whether it is part of a line of either geometry and whether
+// the area either side of it is inside a polygon of either geometry.
+func topologicallyEqual(a GeometrySubtype, b GeometrySubtype, tolerance float64) bool {
+	var sets [2]pointSet
+	for i, g := range []GeometrySubtype{a, b} {
+		if g == nil || sets[i].add(g) != nil {
+			return false
+		}
+	}
+
+	// Segments of lines and polygon rings, and which geometry and polygon
+	// each belongs to, with -1 for lines
+	var segments [][2][]float64
+	var owners [][2]int
+	var polygons [][][][2]float64
+	var polygonSet []int
+	for i, s := range sets {
+		for j := range s.lines {
+			segments = append(segments, [2][]float64{s.lines[j][0][:], s.lines[j][1][:]})
+			owners = append(owners, [2]int{i, -1})
+		}
+		for _, polygon := range s.polygons {
+			for _, ring := range polygon {
+				for k := 1; k < len(ring); k++ {
+					segments = append(segments, [2][]float64{ring[k-1][:], ring[k][:]})
+					owners = append(owners, [2]int{i, len(polygons)})
+				}
+			}
+			polygons = append(polygons, polygon)
+			polygonSet = append(polygonSet, i)
+		}
+	}
+
+	g := ringGraph{dimensions: XY, index: map[[2]float64]int{}, tolerance: tolerance}
+	edges, _ := g.split(segments)
+
+	// Polygons each edge is a boundary of, and geometries it is a line of
+	boundaries := make([][]int, len(edges))
+	lines := make([][2]bool, len(edges))
+	for i, e := range edges {
+		count := map[int]int{}
+		for _, s := range e.sources {
+			if owner := owners[s]; owner[1] < 0 {
+				lines[i][owner[0]] = true
+			} else {
+				count[owner[1]]++
+			}
+		}
+		for polygon, n := range count {
+			if n%2 == 1 {
+				boundaries[i] = append(boundaries[i], polygon)
+			}
+		}
+	}
+
+	// Whether the area left and right of each edge is inside each geometry
+	left := make([][2]bool, len(edges))
+	right := make([][2]bool, len(edges))
+	for i, e := range edges {
+		p, q := g.nodes[e.nodes[0]].coords, g.nodes[e.nodes[1]].coords
+		in := make([]bool, len(polygons))
+		for j, f := range edges {
+			if j != i && len(boundaries[j]) > 0 && leftRayCrosses(p, q, g.nodes[f.nodes[0]].coords, g.nodes[f.nodes[1]].coords) {
+				for _, polygon := range boundaries[j] {
+					in[polygon] = !in[polygon]
+				}
+			}
+		}
+		for polygon, inLeft := range in {
+			set := polygonSet[polygon]
+			left[i][set] = left[i][set] || inLeft
+			right[i][set] = right[i][set] || inLeft != containsInt(boundaries[i], polygon)
+		}
+	}
+
+	// The areas must match either side of every edge, and lines of each
+	// geometry be lines or in the area of the other
+	for i := range edges {
+		if left[i][0] != left[i][1] || right[i][0] != right[i][1] {
+			return false
+		}
+		for j := 0; j < 2; j++ {
+			other := 1 - j
+			if lines[i][j] && !lines[i][other] && !left[i][other] && !right[i][other] {
+				return false
+			}
+		}
+	}
+
+	// Points of each geometry must be covered by the other
+	covered := func(p [2]float64, set int) bool {
+		for _, q := range sets[set].points {
+			if coordsWithin(p[:], q[:], tolerance) {
+				return true
+			}
+		}
+		for i, e := range edges {
+			if !lines[i][set] && !left[i][set] && !right[i][set] {
+				continue
+			}
+			a, b := xy(g.nodes[e.nodes[0]].coords), xy(g.nodes[e.nodes[1]].coords)
+			if onSegment(p, a, b) || distanceToSegment(p, a, b) <= tolerance {
+				return true
+			}
+		}
+		for polygon, rings := range polygons {
+			if polygonSet[polygon] == set && locateInPolygon(p, rings) != outside {
+				return true
+			}
+		}
+		return false
+	}
+	for i, s := range sets {
+		for _, p := range s.points {
+			if !covered(p, 1-i) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Report whether a slice contains a value
+func containsInt(s []int, v int) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Report whether the GISGeometry equals another, as ST_OrderingEquals or as
+// set by the EqualOptions. Geometries without an SRID have SRID 0.
+func (g GISGeometry) Equals(other GISGeometry, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if !o.IgnoreSRID && g.srid() != other.srid() {
+		return false
+	}
+	if g.Geometry == nil || other.Geometry == nil {
+		return g.Geometry == nil && other.Geometry == nil
+	}
+	return g.Geometry.Equals(other.Geometry, o)
+}
+
+// Get the SRID of the GISGeometry, or 0 if it has none
+func (g GISGeometry) srid() uint32 {
+	if !g.SRIDFlag {
+		return 0
+	}
+	return g.SRID
+}
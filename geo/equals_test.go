@@ -0,0 +1,106 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestEquals(t *testing.T) {
+	square := xyPolygon(xyRing(0, 0, 2, 0, 2, 2, 0, 2, 0, 0))
+	rotated := xyPolygon(xyRing(2, 2, 0, 2, 0, 0, 2, 0, 2, 2))
+	reversed := xyPolygon(xyRing(0, 0, 0, 2, 2, 2, 2, 0, 0, 0))
+	halves := &geo.MultiPolygon{Polygons: []geo.Polygon{
+		*xyPolygon(xyRing(0, 0, 1, 0, 1, 2, 0, 2, 0, 0)),
+		*xyPolygon(xyRing(1, 0, 2, 0, 2, 2, 1, 2, 1, 0)),
+	}, Dimensions: geo.XY}
+	arc := &geo.CompoundCurve{Geometry: []geo.GeometrySubtype{
+		xyCircularString(0, 0, 1, 1, 2, 0), xyLineString(2, 0, 0, 0)}, Dimensions: geo.XY}
+	arcReversed := &geo.CompoundCurve{Geometry: []geo.GeometrySubtype{
+		xyLineString(0, 0, 2, 0), xyCircularString(2, 0, 1, 1, 0, 0)}, Dimensions: geo.XY}
+
+	exact := geo.EqualOptions{}
+	ring2 := geo.EqualOptions{IgnoreRingStart: true, IgnoreOrientation: true}
+	topological := geo.EqualOptions{Topological: true}
+
+	tests := []struct {
+		name  string
+		a, b  geo.GeometrySubtype
+		opts  geo.EqualOptions
+		equal bool
+	}{
+		{"same point", xyPoint(1, 2), xyPoint(1, 2), exact, true},
+		{"point value", xyPoint(1, 2), *xyPoint(1, 2), exact, true},
+		{"different point", xyPoint(1, 2), xyPoint(1, 3), exact, false},
+		{"point within tolerance", xyPoint(1, 2), xyPoint(1, 2.001), geo.EqualOptions{Tolerance: 0.01}, true},
+		{"point beyond tolerance", xyPoint(1, 2), xyPoint(1, 2.1), geo.EqualOptions{Tolerance: 0.01}, false},
+		{"different dimensions", xyPoint(1, 2), &geo.Point{Coords: []float64{1, 2, 0}, Dimensions: geo.XYZ}, exact, false},
+		{"different types", xyPoint(1, 2), &geo.MultiPoint{Coords: []float64{1, 2}, Dimensions: geo.XY}, exact, false},
+		{"rotated ring", square, rotated, exact, false},
+		{"rotated ring ignoring start", square, rotated, geo.EqualOptions{IgnoreRingStart: true}, true},
+		{"reversed ring ignoring start", square, reversed, geo.EqualOptions{IgnoreRingStart: true}, false},
+		{"reversed ring ignoring orientation", square, reversed, ring2, true},
+		{"open linestring reversed", xyLineString(0, 0, 1, 1), xyLineString(1, 1, 0, 0), ring2, false},
+		{"circularstring rotated by an arc", xyCircularString(0, 0, 1, 1, 2, 0, 1, -1, 0, 0), xyCircularString(2, 0, 1, -1, 0, 0, 1, 1, 2, 0), ring2, true},
+		{"circularstring rotated within an arc", xyCircularString(0, 0, 1, 1, 2, 0, 1, -1, 0, 0), xyCircularString(1, 1, 2, 0, 1, -1, 0, 0, 1, 1), ring2, false},
+		{"compoundcurve", arc, arc, exact, true},
+		{"compoundcurve of other member", arc, &geo.CompoundCurve{Geometry: []geo.GeometrySubtype{
+			xyCircularString(0, 0, 1, 2, 2, 0), xyLineString(2, 0, 0, 0)}, Dimensions: geo.XY}, exact, false},
+		{"compoundcurve reversed", arc, arcReversed, exact, false},
+		{"compoundcurve reversed ignoring orientation", arc, arcReversed, ring2, true},
+		{"topological polygon rotated", square, rotated, topological, true},
+		{"topological polygon halves", square, halves, topological, true},
+		{"topological polygon larger", square, xyPolygon(xyRing(0, 0, 3, 0, 3, 3, 0, 3, 0, 0)), topological, false},
+		{"topological polygon with hole", square, xyPolygon(xyRing(0, 0, 2, 0, 2, 2, 0, 2, 0, 0), xyRing(0.5, 0.5, 0.5, 1, 1, 1, 0.5, 0.5)), topological, false},
+		{"topological linestring split", xyLineString(0, 0, 2, 0), &geo.MultiLineString{LineStrings: []geo.LineString{
+			*xyLineString(0, 0, 1, 0), *xyLineString(2, 0, 1, 0)}, Dimensions: geo.XY}, topological, true},
+		{"topological linestring extra vertex", xyLineString(0, 0, 2, 0), xyLineString(0, 0, 1, 0, 2, 0), topological, true},
+		{"topological linestring shorter", xyLineString(0, 0, 2, 0), xyLineString(0, 0, 1, 0), topological, false},
+		{"topological linestring and polygon", xyLineString(0, 0, 2, 0, 2, 2, 0, 2, 0, 0), square, topological, false},
+		{"topological points", &geo.MultiPoint{Coords: []float64{0, 0, 1, 1, 0, 0}, Dimensions: geo.XY},
+			&geo.MultiPoint{Coords: []float64{1, 1, 0, 0}, Dimensions: geo.XY}, topological, true},
+		{"topological collection", &geo.GeometryCollection{Geometry: []geo.GeometrySubtype{square, xyLineString(1, 1, 2, 2), xyPoint(0, 0)}, Dimensions: geo.XY},
+			rotated, topological, true},
+		{"topological collection with outer line", &geo.GeometryCollection{Geometry: []geo.GeometrySubtype{square, xyLineString(1, 1, 3, 3)}, Dimensions: geo.XY},
+			rotated, topological, false},
+		{"topological curve", &geo.CurvePolygon{Geometry: []geo.GeometrySubtype{xyCircularString(0, 0, 2, 0, 0, 0)}, Dimensions: geo.XY},
+			&geo.CurvePolygon{Geometry: []geo.GeometrySubtype{xyCircularString(2, 0, 0, 0, 2, 0)}, Dimensions: geo.XY}, topological, true},
+		{"topological within tolerance", square, xyPolygon(xyRing(0, 0, 2, 0, 2, 2.001, 0, 2, 0, 0)), geo.EqualOptions{Topological: true, Tolerance: 0.01}, true},
+	}
+	for _, test := range tests {
+		if equal := test.a.Equals(test.b, test.opts); equal != test.equal {
+			t.Errorf("%v: expected %v equals %v to be %v", test.name, test.a, test.b, test.equal)
+		}
+		if equal := test.b.Equals(test.a, test.opts); equal != test.equal {
+			t.Errorf("%v: expected %v equals %v to be %v", test.name, test.b, test.a, test.equal)
+		}
+	}
+}
+
+func TestGISGeometryEquals(t *testing.T) {
+	a := geo.NewGISGeometry(makeTestMultiPolygon(t, 2))
+	b := geo.NewGISGeometry(makeTestMultiPolygon(t, 2))
+	b.Geometry = a.Geometry
+	if !a.Equals(b) {
+		t.Errorf("geometry %v did not equal %v", a, b)
+	}
+
+	b.SetSRID(4326)
+	if a.Equals(b) {
+		t.Errorf("geometry %v equalled %v with a different SRID", a, b)
+	}
+	if !a.Equals(b, geo.EqualOptions{IgnoreSRID: true}) {
+		t.Errorf("geometry %v did not equal %v ignoring SRID", a, b)
+	}
+
+	collection := func(srids map[int]uint32) geo.GeometrySubtype {
+		return &geo.GeometryCollection{Geometry: []geo.GeometrySubtype{
+			&geo.Point{Coords: []float64{1, 2}, Dimensions: geo.XY}}, Dimensions: geo.XY, MemberSRIDs: srids}
+	}
+	if collection(nil).Equals(collection(map[int]uint32{0: 4326})) {
+		t.Error("collections with different member SRIDs were equal")
+	}
+	if !collection(nil).Equals(collection(map[int]uint32{0: 4326}), geo.EqualOptions{IgnoreSRID: true}) {
+		t.Error("collections with different member SRIDs were not equal ignoring SRID")
+	}
+}
@@ -0,0 +1,118 @@
+package geo_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+// An entry of the golden EWKB corpus in testdata/ewkb_corpus.json
+type corpusEntry struct {
+	Name  string `json:"name"`
+	EWKT  string `json:"ewkt"`            // EWKT of the geometry, as ST_AsEWKT writes it
+	EWKB  string `json:"ewkb"`            // canonical hex EWKB, as ST_AsEWKB(geometry, 'NDR') writes it
+	Input string `json:"input,omitempty"` // non-canonical hex EWKB decoding to the geometry, if any
+}
+
+var updateCorpus = flag.Bool("update-corpus", false, "rewrite the canonical EWKB and EWKT of testdata/ewkb_corpus.json from PostGIS")
+
+func TestEWKBCorpus(t *testing.T) {
+	for _, entry := range loadTestCorpus(t) {
+		t.Run(entry.Name, func(t *testing.T) {
+			canonical := scanTestCorpus(t, entry.EWKB)
+			if ewkt := canonical.EWKT(); ewkt != entry.EWKT {
+				t.Errorf("written as %v, expected %v", ewkt, entry.EWKT)
+			}
+			if entry.Input == "" {
+				return
+			}
+
+			// Other input is equal to the canonical geometry, other than
+			// embedded SRIDs of members, which are re-encoded as they were read
+			g := scanTestCorpus(t, entry.Input)
+			if g.SRID != canonical.SRID || !g.Equals(canonical, geo.EqualOptions{IgnoreSRID: true}) {
+				t.Errorf("input decoded as %v, expected %v", g, canonical)
+			}
+			if ewkt := g.EWKT(); ewkt != entry.EWKT {
+				t.Errorf("input written as %v, expected %v", ewkt, entry.EWKT)
+			}
+		})
+	}
+}
+
+// Decode hex EWKB, checking it is re-encoded as the same bytes
+func scanTestCorpus(t *testing.T, hexewkb string) geo.GISGeometry {
+	t.Helper()
+	var g geo.GISGeometry
+	if err := g.Scan([]byte(hexewkb)); err != nil {
+		t.Fatal(err)
+	}
+	value, err := g.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ewkb := strings.ToUpper(string(value.([]byte))); ewkb != strings.ToUpper(hexewkb) {
+		t.Errorf("re-encoded as\n%v, expected\n%v", ewkb, hexewkb)
+	}
+	return g
+}
+
+// Check the corpus against PostGIS, which should read each input and write
+// the canonical EWKB and EWKT. With -update-corpus the corpus is rewritten
+// with what PostGIS writes.
+func TestEWKBCorpusPostGIS(t *testing.T) {
+	if !testPostGIS {
+		t.Skip("requires PostGIS")
+	}
+	corpus := loadTestCorpus(t)
+	for i, entry := range corpus {
+		input := entry.EWKB
+		if entry.Input != "" {
+			input = entry.Input
+		}
+
+		var ewkt, ewkb string
+		err := testDB.QueryRow(`SELECT ST_AsEWKT(g), upper(encode(ST_AsEWKB(g, 'NDR'), 'hex'))
+			FROM (SELECT $1::text::geometry AS g) AS corpus`, input).Scan(&ewkt, &ewkb)
+		if err != nil {
+			t.Errorf("%v: %v", entry.Name, err)
+			continue
+		}
+		if *updateCorpus {
+			corpus[i].EWKB, corpus[i].EWKT = ewkb, ewkt
+			continue
+		}
+		if ewkb != entry.EWKB {
+			t.Errorf("%v: PostGIS wrote\n%v, expected\n%v", entry.Name, ewkb, entry.EWKB)
+		}
+		if ewkt != entry.EWKT {
+			t.Errorf("%v: PostGIS wrote %v, expected %v", entry.Name, ewkt, entry.EWKT)
+		}
+	}
+
+	if *updateCorpus {
+		data, err := json.MarshalIndent(corpus, "", "\t")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile("testdata/ewkb_corpus.json", append(data, '\n'), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func loadTestCorpus(t testing.TB) []corpusEntry {
+	data, err := os.ReadFile("testdata/ewkb_corpus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var corpus []corpusEntry
+	if err := json.Unmarshal(data, &corpus); err != nil {
+		t.Fatal(err)
+	}
+	return corpus
+}
@@ -0,0 +1,362 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+Helpers for the EWKB decoders. Input may come from untrusted sources, so every
+read is checked against the remaining length of the buffer and returns an
+error rather than panicking on truncated or malformed data.
+
+Errors are returned as a *DecodeError giving where in the input and in the
+geometry decoding failed, wrapping the cause.
+*/
+
+// DecodeError is returned when EWKB can not be decoded
+type DecodeError struct {
+	Offset   int               // byte offset in the input at which decoding failed
+	Path     string            // path to the failing element, such as MultiPolygon[3].ring[1].point[17]
+	Expected []GISGeometryType // types allowed, when a geometry is of the wrong type
+	Actual   GISGeometryType   // type found, when a geometry is of the wrong type
+	Err      error             // the cause
+}
+
+func (e *DecodeError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("ewkb decode error at byte " + strconv.Itoa(e.Offset))
+	if e.Path != "" {
+		sb.WriteString(" in " + e.Path)
+	}
+	sb.WriteString(": " + e.Err.Error())
+	return sb.String()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// An element of the path to the geometry being decoded. Index is -1 for
+// elements that are not one of a sequence.
+type pathElement struct {
+	name  string
+	index int
+}
+
+// Tracks the position in the input, the path to the geometry being decoded and
+// the limits of the DecodeOptions while decoding a geometry
+type decodeState struct {
+	opts   DecodeOptions
+	length int   // length of the input
+	coords int64 // points read so far
+	depth  int   // current nesting of multi geometries
+	path   []pathElement
+
+	// SRID of the geometry, or of the first member with an embedded SRID
+	// if the geometry has none
+	srid      uint32
+	sridKnown bool
+}
+
+// Start decoding input of the given length with the options, using the
+// defaults if none are given. A name, if given, starts the path.
+func newDecodeState(name string, length int, opts []DecodeOptions) (*decodeState, error) {
+	o := DefaultDecodeOptions
+	if len(opts) > 0 {
+		o = opts[0].withDefaults()
+	}
+	d := &decodeState{opts: o, length: length}
+	if name != "" {
+		d.push(name)
+	}
+	if err := checkLimit("MaxInputSize", o.MaxInputSize, int64(length)); err != nil {
+		return nil, d.errorAt(0, err)
+	}
+	return d, nil
+}
+
+// Get the name of a geometry type as used in paths
+func pathName(t GISGeometryType) string {
+	return strings.TrimSuffix(t.String(), "Type")
+}
+
+// Add an element to the path
+func (d *decodeState) push(name string) {
+	d.path = append(d.path, pathElement{name: name, index: -1})
+}
+
+// Remove the last element of the path
+func (d *decodeState) pop() {
+	d.path = d.path[:len(d.path)-1]
+}
+
+// Set the index of the last element of the path
+func (d *decodeState) at(i int) {
+	d.path[len(d.path)-1].index = i
+}
+
+// Get the path, with any further elements
+func (d *decodeState) pathString(extra ...pathElement) string {
+	var sb strings.Builder
+	for i, e := range append(append([]pathElement(nil), d.path...), extra...) {
+		if i > 0 {
+			sb.WriteString(".")
+		}
+		sb.WriteString(e.name)
+		if e.index >= 0 {
+			sb.WriteString("[" + strconv.Itoa(e.index) + "]")
+		}
+	}
+	return sb.String()
+}
+
+// Get the offset in the input of the next byte of the buffer
+func (d *decodeState) offset(b *bytes.Buffer) int {
+	return d.length - b.Len()
+}
+
+// Create a DecodeError at the given offset and the current path
+func (d *decodeState) errorAt(offset int, err error, extra ...pathElement) *DecodeError {
+	return &DecodeError{Offset: offset, Path: d.pathString(extra...), Err: err}
+}
+
+// Create a DecodeError at the current position of the buffer and the current path
+func (d *decodeState) errorf(b *bytes.Buffer, format string, args ...interface{}) *DecodeError {
+	return d.errorAt(d.offset(b), fmt.Errorf(format, args...))
+}
+
+// Read the uint32 count of points, rings or members that starts the body of a
+// geometry, within MaxElements
+func (d *decodeState) readCount(b *bytes.Buffer, name string) (uint32, error) {
+	if b.Len() < 4 {
+		return 0, d.errorf(b, "input for %v is too short (%v) to contain its length", name, b.Len())
+	}
+	offset := d.offset(b)
+	count := binary.LittleEndian.Uint32(b.Next(4))
+	if err := checkLimit("MaxElements", d.opts.MaxElements, int64(count)); err != nil {
+		return 0, d.errorAt(offset, err)
+	}
+	return count, nil
+}
+
+// Report a problem with the input that decoding has repaired or ignored
+func (d *decodeState) warn(offset int, format string, args ...interface{}) {
+	if d.opts.Warn != nil {
+		d.opts.Warn(d.errorAt(offset, fmt.Errorf(format, args...)))
+	}
+}
+
+// The header that starts each member of a multi geometry
+type memberHeader struct {
+	offset     int // of the byte order marker
+	geoType    GISGeometryType
+	dimensions Dimensions // the member is encoded with
+	sridFlag   bool
+	srid       uint32
+}
+
+// Read the header of a member of a multi geometry. Members must be little
+// endian, and of one of the allowed types if any are given.
+//
+// Members should have the dimensions of their parent. The dimensions the
+// member is encoded with are returned, which in DecodeLenient may have
+// ordinates the parent does not, to be removed with forceDimensions.
+//
+// An embedded SRID must match the SRID of the geometry. In DecodeLenient one
+// that does not is dropped.
+func (d *decodeState) readMemberHeader(b *bytes.Buffer, name string, dimensions Dimensions, allowed ...GISGeometryType) (memberHeader, error) {
+	h := memberHeader{offset: d.offset(b)}
+	if b.Len() < 5 {
+		return h, d.errorf(b, "input for %v member is too short (%v) to contain its type", name, b.Len())
+	}
+	if bom, _ := b.ReadByte(); ByteOrder(bom) != LittleEndian {
+		return h, d.errorAt(h.offset, fmt.Errorf("%v member has unsupported byte order %v", name, bom))
+	}
+	h.geoType, h.sridFlag, h.dimensions = decodeGeotype(b.Next(4))
+	if h.sridFlag {
+		if b.Len() < 4 {
+			return h, d.errorf(b, "input for %v member is too short (%v) to contain its srid", name, b.Len())
+		}
+		h.srid = binary.LittleEndian.Uint32(b.Next(4))
+		switch {
+		case !d.sridKnown:
+			d.srid, d.sridKnown = h.srid, true
+		case h.srid != d.srid && d.opts.Mode == DecodeLenient:
+			d.warn(h.offset, "dropped embedded srid %v of %v member, expected %v", h.srid, name, d.srid)
+			h.sridFlag, h.srid = false, 0
+		case h.srid != d.srid:
+			return h, d.errorAt(h.offset, fmt.Errorf("%v member has srid %v, expected %v", name, h.srid, d.srid))
+		}
+	}
+
+	if len(allowed) > 0 && !containsType(allowed, h.geoType) {
+		err := d.errorAt(h.offset, fmt.Errorf("%v must not contain %v", name, h.geoType))
+		err.Expected, err.Actual = allowed, h.geoType
+		return h, err
+	}
+
+	if h.dimensions != dimensions {
+		if d.opts.Mode != DecodeLenient || !hasDimensions(h.dimensions, dimensions) {
+			return h, d.errorAt(h.offset, fmt.Errorf("%v member has dimensions %v, expected %v", name, h.dimensions, dimensions))
+		}
+		d.warn(h.offset, "reduced %v member from dimensions %v to %v", name, h.dimensions, dimensions)
+	}
+	return h, nil
+}
+
+// Read the header of a member of a multi geometry that does not keep the
+// SRIDs of its members, returning the type and the dimensions the member is
+// encoded with. Embedded SRIDs are ignored, except in DecodeStrict where they
+// are rejected.
+func (d *decodeState) readMemberType(b *bytes.Buffer, name string, dimensions Dimensions, allowed ...GISGeometryType) (GISGeometryType, Dimensions, error) {
+	h, err := d.readMemberHeader(b, name, dimensions, allowed...)
+	if err != nil {
+		return UNKNOWN, UNSET, err
+	}
+	if h.sridFlag {
+		switch d.opts.Mode {
+		case DecodeStrict:
+			return UNKNOWN, UNSET, d.errorAt(h.offset, fmt.Errorf("%v member has an embedded srid %v", name, h.srid))
+		case DecodeLenient:
+			d.warn(h.offset, "ignored embedded srid %v of %v member", h.srid, name)
+		}
+	}
+	return h.geoType, h.dimensions, nil
+}
+
+// Report whether a geometry type is one of the types
+func containsType(types []GISGeometryType, t GISGeometryType) bool {
+	for _, u := range types {
+		if u == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Read count points of a sequence into a flat coordinate slice, within
+// MaxCoords for the whole geometry. Truncated input is reported at the
+// first point that is missing.
+func (d *decodeState) readCoords(b *bytes.Buffer, dimensions Dimensions, count uint32) ([]float64, error) {
+	if err := checkLimit("MaxCoords", d.opts.MaxCoords, d.coords+int64(count)); err != nil {
+		return nil, d.errorAt(d.offset(b), err)
+	}
+	stride := dimensions.Stride()
+	if stride > 0 && uint64(b.Len()) < uint64(count)*uint64(stride)*8 {
+		available := b.Len() / (stride * 8)
+		return nil, d.errorAt(d.offset(b)+available*stride*8,
+			fmt.Errorf("input is too short (%v) for %v points of dimensions %v", b.Len(), count, dimensions),
+			pathElement{name: "point", index: available})
+	}
+	offset := d.offset(b)
+	coords, err := readCoords(b, dimensions, count)
+	if err != nil {
+		return nil, d.errorAt(offset, err)
+	}
+	d.coords += int64(count)
+	return coords, nil
+}
+
+// Read a single point into a coordinate slice, within MaxCoords for the whole geometry
+func (d *decodeState) readPoint(b *bytes.Buffer, dimensions Dimensions) ([]float64, error) {
+	if err := checkLimit("MaxCoords", d.opts.MaxCoords, d.coords+1); err != nil {
+		return nil, d.errorAt(d.offset(b), err)
+	}
+	offset := d.offset(b)
+	coords, err := readCoords(b, dimensions, 1)
+	if err != nil {
+		return nil, d.errorAt(offset, err)
+	}
+	d.coords++
+	return coords, nil
+}
+
+// Enter the members of a multi geometry, within MaxDepth. Leave must be called
+// when the members are read.
+func (d *decodeState) enter(b *bytes.Buffer) error {
+	d.depth++
+	if err := checkLimit("MaxDepth", d.opts.MaxDepth, int64(d.depth)); err != nil {
+		return d.errorAt(d.offset(b), err)
+	}
+	return nil
+}
+
+func (d *decodeState) leave() {
+	d.depth--
+}
+
+// Reduce a decoded geometry to the given dimensions, which its own dimensions
+// must have, removing the other ordinates
+func forceDimensions(g GeometrySubtype, dimensions Dimensions) {
+	if g.GetDimensions() == dimensions {
+		return
+	}
+	switch t := g.(type) {
+	case *Point:
+		t.Coords, t.Dimensions = projectCoords(t.Coords, t.Dimensions, dimensions), dimensions
+	case *LineString:
+		t.Coords, t.Dimensions = projectCoords(t.Coords, t.Dimensions, dimensions), dimensions
+	case *LinearRing:
+		t.Coords, t.Dimensions = projectCoords(t.Coords, t.Dimensions, dimensions), dimensions
+	case *CircularString:
+		t.Coords, t.Dimensions = projectCoords(t.Coords, t.Dimensions, dimensions), dimensions
+	case *MultiPoint:
+		t.Coords, t.Dimensions = projectCoords(t.Coords, t.Dimensions, dimensions), dimensions
+	case *Triangle:
+		t.Coords, t.Dimensions = projectCoords(t.Coords, t.Dimensions, dimensions), dimensions
+	case *Polygon:
+		for i := range t.LinearRings {
+			forceDimensions(&t.LinearRings[i], dimensions)
+		}
+		t.Dimensions = dimensions
+	case *MultiLineString:
+		for i := range t.LineStrings {
+			forceDimensions(&t.LineStrings[i], dimensions)
+		}
+		t.Dimensions = dimensions
+	case *MultiPolygon:
+		for i := range t.Polygons {
+			forceDimensions(&t.Polygons[i], dimensions)
+		}
+		t.Dimensions = dimensions
+	case *PolyHedralSurface:
+		for i := range t.Polygons {
+			forceDimensions(&t.Polygons[i], dimensions)
+		}
+		t.Dimensions = dimensions
+	case *TIN:
+		for i := range t.Triangles {
+			forceDimensions(&t.Triangles[i], dimensions)
+		}
+		t.Dimensions = dimensions
+	case *CompoundCurve:
+		for _, member := range t.Geometry {
+			forceDimensions(member, dimensions)
+		}
+		t.Dimensions = dimensions
+	case *CurvePolygon:
+		for _, member := range t.Geometry {
+			forceDimensions(member, dimensions)
+		}
+		t.Dimensions = dimensions
+	case *MultiCurve:
+		for _, member := range t.Geometry {
+			forceDimensions(member, dimensions)
+		}
+		t.Dimensions = dimensions
+	case *MultiSurface:
+		for _, member := range t.Geometry {
+			forceDimensions(member, dimensions)
+		}
+		t.Dimensions = dimensions
+	case *GeometryCollection:
+		for _, member := range t.Geometry {
+			forceDimensions(member, dimensions)
+		}
+		t.Dimensions = dimensions
+	}
+}
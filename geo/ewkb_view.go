@@ -0,0 +1,333 @@
+package geo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+)
+
+/*
+An EWKBView is a read-only view over raw (binary, not hex encoded) EWKB bytes.
+
+It exposes the header fields, element counts and coordinates of a geometry by
+reading directly from the underlying bytes, without decoding the geometry into
+its GeometrySubtype structs. This makes it suitable for filtering pipelines
+that only need the type, SRID, dimensions or bounds of many geometries. A view
+can be promoted to a full GISGeometry on demand.
+
+The view retains the byte slice it was created from, which must not be modified
+while the view is in use. Nesting of the geometry is limited by the MaxDepth of
+the DecodeOptions the view was created with, which also limit Geometry.
+
+The zero EWKBView holds no geometry. Its header accessors return zero values,
+and its other methods return an error.
+*/
+type EWKBView struct {
+	ewkb []byte
+	opts DecodeOptions
+}
+
+// Create a new EWKBView over binary EWKB data, with nesting limited by the
+// DecodeOptions if given. Only the header is checked, the remainder of the
+// geometry is validated as it is read.
+func NewEWKBView(ewkb []byte, opts ...DecodeOptions) (EWKBView, error) {
+	if len(ewkb) < 5 {
+		return EWKBView{}, fmt.Errorf("ewkb must be at least 5 bytes to contain byte order and type")
+	}
+	if ByteOrder(ewkb[0]) != LittleEndian {
+		return EWKBView{}, fmt.Errorf("big endian is currently unsupported")
+	}
+	_, sridFlag, _ := decodeGeotype(ewkb[1:5])
+	if sridFlag && len(ewkb) < 9 {
+		return EWKBView{}, fmt.Errorf("ewkb must be at least 9 bytes to contain byte order, type, and srid")
+	}
+	o := DefaultDecodeOptions
+	if len(opts) > 0 {
+		o = opts[0].withDefaults()
+	}
+	return EWKBView{ewkb: ewkb, opts: o}, nil
+}
+
+// Used to map hex encoded EWKB into a view when read by the database driver
+func (v *EWKBView) Scan(value interface{}) error {
+	hexewkb, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("scan expected []byte, got %T (%v)", value, value)
+	}
+
+	ewkb := make([]byte, hex.DecodedLen(len(hexewkb)))
+	_, err := hex.Decode(ewkb, hexewkb)
+	if err != nil {
+		return err
+	}
+
+	view, err := NewEWKBView(ewkb)
+	if err != nil {
+		return err
+	}
+	*v = view
+	return nil
+}
+
+// Populate the view from a copy of binary EWKB, implementing encoding.BinaryUnmarshaler
+func (v *EWKBView) UnmarshalBinary(ewkb []byte) error {
+	view, err := NewEWKBView(append([]byte(nil), ewkb...))
+	if err != nil {
+		return err
+	}
+	*v = view
+	return nil
+}
+
+// Get the raw EWKB bytes underlying the view
+func (v EWKBView) Bytes() []byte {
+	return v.ewkb
+}
+
+// Get the type, SRID flag and dimensions of the header, or zero values if the
+// view has no header
+func (v EWKBView) header() (GISGeometryType, bool, Dimensions) {
+	if len(v.ewkb) < 5 {
+		return 0, false, 0
+	}
+	return decodeGeotype(v.ewkb[1:5])
+}
+
+// Get the byte order of the geometry
+func (v EWKBView) ByteOrder() ByteOrder {
+	if len(v.ewkb) < 5 {
+		return 0
+	}
+	return ByteOrder(v.ewkb[0])
+}
+
+// Get the type of the geometry
+func (v EWKBView) GeoType() GISGeometryType {
+	geoType, _, _ := v.header()
+	return geoType
+}
+
+// Get the dimensions of the geometry
+func (v EWKBView) Dimensions() Dimensions {
+	_, _, dimensions := v.header()
+	return dimensions
+}
+
+// Report whether the geometry has an embedded SRID
+func (v EWKBView) HasSRID() bool {
+	_, sridFlag, _ := v.header()
+	return sridFlag
+}
+
+// Get the SRID of the geometry, or 0 if none is embedded
+func (v EWKBView) SRID() uint32 {
+	if !v.HasSRID() || len(v.ewkb) < 9 {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(v.ewkb[5:9])
+}
+
+// Get the offset of the geometry body, following the header
+func (v EWKBView) bodyOffset() int {
+	if v.HasSRID() {
+		return 9
+	}
+	return 5
+}
+
+// Get the number of top level elements in the geometry: 1 for a Point, the
+// number of points for LineStrings and CircularStrings, the number of rings for
+// Polygons and Triangles, and the number of members for all other types.
+func (v EWKBView) NumElements() (int, error) {
+	if len(v.ewkb) < 5 {
+		return 0, fmt.Errorf("ewkb is too short (%v) to contain a geometry header", len(v.ewkb))
+	}
+	if v.GeoType() == PointType {
+		return 1, nil
+	}
+	offset := v.bodyOffset()
+	if len(v.ewkb) < offset+4 {
+		return 0, fmt.Errorf("ewkb is too short (%v) to contain an element count", len(v.ewkb))
+	}
+	return int(binary.LittleEndian.Uint32(v.ewkb[offset:])), nil
+}
+
+// Get the total number of coordinates in the geometry, including those of any
+// nested geometries.
+func (v EWKBView) NumCoords() (int, error) {
+	w := ewkbWalker{ewkb: v.ewkb, maxDepth: v.opts.MaxDepth}
+	if _, err := w.geometry(0); err != nil {
+		return 0, err
+	}
+	return w.count, nil
+}
+
+// Call fn with the ordinates of each coordinate in the geometry in order, until
+// fn returns false. The slice passed to fn is reused between calls and must not
+// be retained.
+func (v EWKBView) EachCoord(fn func(coord []float64) bool) error {
+	w := ewkbWalker{ewkb: v.ewkb, visit: fn, maxDepth: v.opts.MaxDepth}
+	_, err := w.geometry(0)
+	return err
+}
+
+// Get the 2D bounds of the coordinates in the geometry. For curved geometry the
+// bounds are those of the control points, which may not contain the whole arc.
+// An error is returned for geometry with no coordinates.
+func (v EWKBView) Bounds() (minX, minY, maxX, maxY float64, err error) {
+	w := ewkbWalker{ewkb: v.ewkb, bounds: true, maxDepth: v.opts.MaxDepth}
+	w.minX, w.minY = math.Inf(1), math.Inf(1)
+	w.maxX, w.maxY = math.Inf(-1), math.Inf(-1)
+	if _, err = w.geometry(0); err != nil {
+		return 0, 0, 0, 0, err
+	}
+	if w.count == 0 {
+		return 0, 0, 0, 0, fmt.Errorf("geometry has no coordinates")
+	}
+	return w.minX, w.minY, w.maxX, w.maxY, nil
+}
+
+// Decode the full geometry, within the DecodeOptions of the view
+func (v EWKBView) Geometry() (GISGeometry, error) {
+	g := GISGeometry{}
+	err := g.decodeEWKB(v.ewkb, v.opts)
+	return g, err
+}
+
+// ewkbWalker reads through the nested structure of EWKB data, counting and
+// visiting coordinates as they are found.
+type ewkbWalker struct {
+	ewkb  []byte
+	coord [4]float64
+	count int
+	visit func(coord []float64) bool
+	done  bool
+
+	depth    int
+	maxDepth int
+
+	bounds                 bool
+	minX, minY, maxX, maxY float64
+}
+
+// Read the count at offset
+func (w *ewkbWalker) length(offset int) (uint32, int, error) {
+	if len(w.ewkb)-offset < 4 {
+		return 0, offset, fmt.Errorf("ewkb is too short (%v) to contain a count at offset %v", len(w.ewkb), offset)
+	}
+	return binary.LittleEndian.Uint32(w.ewkb[offset:]), offset + 4, nil
+}
+
+// Walk a geometry with its own header starting at offset, returning the offset following it
+func (w *ewkbWalker) geometry(offset int) (int, error) {
+	if len(w.ewkb)-offset < 5 {
+		return offset, fmt.Errorf("ewkb is too short (%v) to contain a geometry header at offset %v", len(w.ewkb), offset)
+	}
+	if ByteOrder(w.ewkb[offset]) != LittleEndian {
+		return offset, fmt.Errorf("big endian is currently unsupported")
+	}
+	geoType, sridFlag, dimensions := decodeGeotype(w.ewkb[offset+1 : offset+5])
+	offset += 5
+	if sridFlag {
+		if len(w.ewkb)-offset < 4 {
+			return offset, fmt.Errorf("ewkb is too short (%v) to contain an srid at offset %v", len(w.ewkb), offset)
+		}
+		offset += 4
+	}
+
+	switch geoType {
+	case PointType:
+		return w.points(offset, dimensions, 1)
+
+	case LineStringType, CircularStringType:
+		count, offset, err := w.length(offset)
+		if err != nil {
+			return offset, err
+		}
+		return w.points(offset, dimensions, count)
+
+	case PolygonType, TriangleType:
+		rings, offset, err := w.length(offset)
+		if err != nil {
+			return offset, err
+		}
+		for i := uint32(0); i < rings && !w.done; i++ {
+			var count uint32
+			count, offset, err = w.length(offset)
+			if err != nil {
+				return offset, err
+			}
+			offset, err = w.points(offset, dimensions, count)
+			if err != nil {
+				return offset, err
+			}
+		}
+		return offset, nil
+
+	case MultiPointType, MultiLineStringType, MultiPolygonType, GeometryCollectionType,
+		CompoundCurveType, CurvePolygonType, MultiCurveType, MultiSurfaceType,
+		PolyHedralSurfaceType, TINType:
+		members, offset, err := w.length(offset)
+		if err != nil {
+			return offset, err
+		}
+		if err := w.enter(offset); err != nil {
+			return offset, err
+		}
+		defer w.leave()
+		for i := uint32(0); i < members && !w.done; i++ {
+			offset, err = w.geometry(offset)
+			if err != nil {
+				return offset, err
+			}
+		}
+		return offset, nil
+
+	default:
+		return offset, fmt.Errorf("unknown geometry type: %v", geoType)
+	}
+}
+
+// Enter a nested geometry at offset, within the MaxDepth of the view
+func (w *ewkbWalker) enter(offset int) error {
+	w.depth++
+	maxDepth := w.maxDepth
+	if maxDepth == 0 {
+		maxDepth = DefaultDecodeOptions.MaxDepth
+	}
+	if err := checkLimit("MaxDepth", maxDepth, int64(w.depth)); err != nil {
+		return fmt.Errorf("geometry at offset %v: %w", offset, err)
+	}
+	return nil
+}
+
+func (w *ewkbWalker) leave() {
+	w.depth--
+}
+
+// Walk count points of the given dimensions starting at offset, returning the offset following them
+func (w *ewkbWalker) points(offset int, dimensions Dimensions, count uint32) (int, error) {
+	stride := dimensions.Stride()
+	size := uint64(count) * uint64(stride) * 8
+	if uint64(len(w.ewkb)-offset) < size {
+		return offset, fmt.Errorf("ewkb is too short (%v) for %v points of dimensions %v at offset %v", len(w.ewkb), count, dimensions, offset)
+	}
+
+	for i := uint32(0); i < count && !w.done; i++ {
+		for j := 0; j < stride; j++ {
+			w.coord[j] = math.Float64frombits(binary.LittleEndian.Uint64(w.ewkb[offset+j*8:]))
+		}
+		offset += stride * 8
+		w.count++
+
+		if w.bounds && !math.IsNaN(w.coord[0]) {
+			w.minX, w.maxX = math.Min(w.minX, w.coord[0]), math.Max(w.maxX, w.coord[0])
+			w.minY, w.maxY = math.Min(w.minY, w.coord[1]), math.Max(w.maxY, w.coord[1])
+		}
+		if w.visit != nil && !w.visit(w.coord[:stride]) {
+			w.done = true
+		}
+	}
+	return offset, nil
+}
@@ -0,0 +1,150 @@
+package geo_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestEWKBView(t *testing.T) {
+
+	gisGeometry := geo.NewGISGeometry(makeTestGeometryCollection(t))
+	gisGeometry.SetSRID(4326)
+
+	view := makeTestEWKBView(t, gisGeometry)
+
+	if view.GeoType() != geo.GeometryCollectionType {
+		t.Errorf("view type %v was not %v", view.GeoType(), geo.GeometryCollectionType)
+	}
+	if view.Dimensions() != geo.XY {
+		t.Errorf("view dimensions %v were not %v", view.Dimensions(), geo.XY)
+	}
+	if !view.HasSRID() || view.SRID() != 4326 {
+		t.Errorf("view srid %v was not 4326", view.SRID())
+	}
+
+	elements, err := view.NumElements()
+	if err != nil {
+		t.Error(err)
+	}
+	if elements != 14 {
+		t.Errorf("view has %v elements, expected 14", elements)
+	}
+
+	coords, err := view.NumCoords()
+	if err != nil {
+		t.Error(err)
+	}
+	visited := 0
+	err = view.EachCoord(func(coord []float64) bool {
+		if len(coord) != 2 {
+			t.Errorf("coordinate %v has %v ordinates, expected 2", coord, len(coord))
+		}
+		visited++
+		return true
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if visited == 0 || visited != coords {
+		t.Errorf("visited %v coordinates, expected %v", visited, coords)
+	}
+
+	// Stop iterating early
+	visited = 0
+	err = view.EachCoord(func(coord []float64) bool {
+		visited++
+		return visited < 3
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if visited != 3 {
+		t.Errorf("visited %v coordinates after stopping, expected 3", visited)
+	}
+
+	gisGeometry2, err := view.Geometry()
+	if err != nil {
+		t.Error(err)
+	}
+	if !cmp.Equal(gisGeometry, gisGeometry2) {
+		t.Errorf("geometrycollection %v was not equal to %v", gisGeometry2, gisGeometry)
+	}
+}
+
+func TestEWKBViewBounds(t *testing.T) {
+
+	lineString, err := geo.LineStringFromCoords([]float64{1, 5, -2, 3, 4, -1}, geo.XY)
+	if err != nil {
+		t.Error(err)
+	}
+	view := makeTestEWKBView(t, geo.NewGISGeometry(lineString))
+
+	minX, minY, maxX, maxY, err := view.Bounds()
+	if err != nil {
+		t.Error(err)
+	}
+	if minX != -2 || minY != -1 || maxX != 4 || maxY != 5 {
+		t.Errorf("bounds %v %v %v %v were not -2 -1 4 5", minX, minY, maxX, maxY)
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		_ = view.EachCoord(func(coord []float64) bool { return true })
+	})
+	if allocs > 1 {
+		t.Errorf("iterating coordinates made %v allocations", allocs)
+	}
+}
+
+func TestEWKBViewTruncated(t *testing.T) {
+
+	view := makeTestEWKBView(t, geo.NewGISGeometry(makeTestPolygon(t)))
+	truncated, err := geo.NewEWKBView(view.Bytes()[:len(view.Bytes())-3])
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = truncated.NumCoords()
+	if err == nil {
+		t.Error("truncated view did not return an error")
+	}
+}
+
+func TestEWKBViewZero(t *testing.T) {
+
+	var view geo.EWKBView
+	if view.GeoType() != 0 || view.HasSRID() || view.SRID() != 0 {
+		t.Errorf("zero view has type %v and srid %v", view.GeoType(), view.SRID())
+	}
+	if _, err := view.NumElements(); err == nil {
+		t.Error("zero view did not return an error counting elements")
+	}
+	if _, err := view.NumCoords(); err == nil {
+		t.Error("zero view did not return an error counting coordinates")
+	}
+	if _, _, _, _, err := view.Bounds(); err == nil {
+		t.Error("zero view did not return an error for bounds")
+	}
+	if _, err := view.Geometry(); err == nil {
+		t.Error("zero view did not return an error decoding the geometry")
+	}
+}
+
+func makeTestEWKBView(t *testing.T, gisGeometry geo.GISGeometry) geo.EWKBView {
+
+	value, err := gisGeometry.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	ewkb, err := hex.DecodeString(string(value.([]byte)))
+	if err != nil {
+		t.Error(err)
+	}
+	view, err := geo.NewEWKBView(ewkb)
+	if err != nil {
+		t.Error(err)
+	}
+	return view
+}
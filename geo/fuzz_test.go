@@ -0,0 +1,187 @@
+package geo_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+// Decoders for the body of each geometry type, following its EWKB header
+var testDecoders = map[geo.GISGeometryType]func(*bytes.Buffer, geo.Dimensions) (geo.GeometrySubtype, error){
+	geo.PointType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.PointFromEWKB(b, d)
+	},
+	geo.LineStringType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.LineStringFromEWKB(b, d)
+	},
+	geo.PolygonType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.PolygonFromEWKB(b, d)
+	},
+	geo.MultiPointType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.MultiPointFromEWKB(b, d)
+	},
+	geo.MultiLineStringType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.MultiLineStringFromEWKB(b, d)
+	},
+	geo.MultiPolygonType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.MultiPolygonFromEWKB(b, d)
+	},
+	geo.GeometryCollectionType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.GeometryCollectionFromEWKB(b, d)
+	},
+	geo.CircularStringType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.CircularStringFromEWKB(b, d)
+	},
+	geo.CompoundCurveType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.CompoundCurveFromEWKB(b, d)
+	},
+	geo.CurvePolygonType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.CurvePolygonFromEWKB(b, d)
+	},
+	geo.MultiCurveType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.MultiCurveFromEWKB(b, d)
+	},
+	geo.MultiSurfaceType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.MultiSurfaceFromEWKB(b, d)
+	},
+	geo.PolyHedralSurfaceType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.PolyhedralSurfaceFromEWKB(b, d)
+	},
+	geo.TINType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.TINFromEWKB(b, d)
+	},
+	geo.TriangleType: func(b *bytes.Buffer, d geo.Dimensions) (geo.GeometrySubtype, error) {
+		return geo.TriangleFromEWKB(b, d)
+	},
+}
+
+// Every truncation of the corpus must fail to decode, rather than panic
+func TestDecodeTruncated(t *testing.T) {
+	for _, entry := range loadTestCorpus(t) {
+		ewkb, err := hex.DecodeString(entry.EWKB)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < len(ewkb); i++ {
+			var g geo.GISGeometry
+			if err := g.UnmarshalBinary(ewkb[:i]); err == nil {
+				t.Errorf("%v truncated to %v bytes decoded without error", entry.Name, i)
+			}
+		}
+	}
+
+	// A linearring with no points
+	if _, err := geo.LinearRingFromEWKB(bytes.NewBuffer([]byte{0, 0, 0, 0}), geo.XY); err == nil {
+		t.Error("linearring of no points decoded without error")
+	}
+}
+
+func FuzzScan(f *testing.F) {
+	for _, entry := range loadTestCorpus(f) {
+		f.Add([]byte(entry.EWKB))
+		f.Add([]byte(entry.EWKB[:len(entry.EWKB)/2]))
+		if entry.Input != "" {
+			f.Add([]byte(entry.Input))
+		}
+	}
+	f.Fuzz(func(t *testing.T, hexewkb []byte) {
+		var g geo.GISGeometry
+		if err := g.Scan(hexewkb); err != nil {
+			return
+		}
+		checkDecoded(t, g.Geometry)
+		if _, err := g.Value(); err != nil {
+			t.Error(err)
+		}
+		_ = g.EWKT()
+	})
+}
+
+func FuzzPointFromEWKB(f *testing.F)              { fuzzDecoder(f, geo.PointType) }
+func FuzzLineStringFromEWKB(f *testing.F)         { fuzzDecoder(f, geo.LineStringType) }
+func FuzzPolygonFromEWKB(f *testing.F)            { fuzzDecoder(f, geo.PolygonType) }
+func FuzzMultiPointFromEWKB(f *testing.F)         { fuzzDecoder(f, geo.MultiPointType) }
+func FuzzMultiLineStringFromEWKB(f *testing.F)    { fuzzDecoder(f, geo.MultiLineStringType) }
+func FuzzMultiPolygonFromEWKB(f *testing.F)       { fuzzDecoder(f, geo.MultiPolygonType) }
+func FuzzGeometryCollectionFromEWKB(f *testing.F) { fuzzDecoder(f, geo.GeometryCollectionType) }
+func FuzzCircularStringFromEWKB(f *testing.F)     { fuzzDecoder(f, geo.CircularStringType) }
+func FuzzCompoundCurveFromEWKB(f *testing.F)      { fuzzDecoder(f, geo.CompoundCurveType) }
+func FuzzCurvePolygonFromEWKB(f *testing.F)       { fuzzDecoder(f, geo.CurvePolygonType) }
+func FuzzMultiCurveFromEWKB(f *testing.F)         { fuzzDecoder(f, geo.MultiCurveType) }
+func FuzzMultiSurfaceFromEWKB(f *testing.F)       { fuzzDecoder(f, geo.MultiSurfaceType) }
+func FuzzPolyhedralSurfaceFromEWKB(f *testing.F)  { fuzzDecoder(f, geo.PolyHedralSurfaceType) }
+func FuzzTINFromEWKB(f *testing.F)                { fuzzDecoder(f, geo.TINType) }
+func FuzzTriangleFromEWKB(f *testing.F)           { fuzzDecoder(f, geo.TriangleType) }
+
+func FuzzLinearRingFromEWKB(f *testing.F) {
+	// Seed with the first ring of each polygon in the corpus, following the ring count
+	for _, seed := range testCorpusBodies(f, geo.PolygonType) {
+		if len(seed.body) > 4 {
+			f.Add(seed.body[4:], byte(seed.dimensions))
+		}
+	}
+	f.Fuzz(func(t *testing.T, body []byte, dimensions byte) {
+		ring, err := geo.LinearRingFromEWKB(bytes.NewBuffer(body), geo.Dimensions(dimensions))
+		if err != nil {
+			return
+		}
+		_ = ring.GetEWKB(false)
+		_ = ring.String()
+	})
+}
+
+// Fuzz the decoder for a geometry type, seeded with the bodies of the
+// geometry of that type in the corpus and their truncations
+func fuzzDecoder(f *testing.F, geoType geo.GISGeometryType) {
+	decode := testDecoders[geoType]
+	for _, seed := range testCorpusBodies(f, geoType) {
+		f.Add(seed.body, byte(seed.dimensions))
+		f.Add(seed.body[:len(seed.body)/2], byte(seed.dimensions))
+	}
+	f.Fuzz(func(t *testing.T, body []byte, dimensions byte) {
+		g, err := decode(bytes.NewBuffer(body), geo.Dimensions(dimensions))
+		if err != nil {
+			return
+		}
+		checkDecoded(t, g)
+	})
+}
+
+// The body of corpus EWKB following its byte order, type and any SRID
+type corpusBody struct {
+	body       []byte
+	dimensions geo.Dimensions
+}
+
+// Get the bodies of the corpus geometry of a type
+func testCorpusBodies(f *testing.F, geoType geo.GISGeometryType) []corpusBody {
+	var bodies []corpusBody
+	for _, entry := range loadTestCorpus(f) {
+		var g geo.GISGeometry
+		if err := g.Scan([]byte(entry.EWKB)); err != nil || g.GeoType != geoType {
+			continue
+		}
+		ewkb, err := hex.DecodeString(entry.EWKB)
+		if err != nil {
+			f.Fatal(err)
+		}
+		header := 5
+		if g.SRIDFlag {
+			header = 9
+		}
+		bodies = append(bodies, corpusBody{body: ewkb[header:], dimensions: g.Dimensions})
+	}
+	return bodies
+}
+
+// Check that decoded geometry can be used without panicking
+func checkDecoded(t *testing.T, g geo.GeometrySubtype) {
+	if g == nil {
+		t.Fatal("decoded geometry was nil without an error")
+	}
+	_ = g.GetEWKB(true)
+	_ = g.String()
+	_ = geo.NewGISGeometry(g).EWKT()
+}
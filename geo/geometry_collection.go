@@ -15,6 +15,10 @@ A GeometryCollection is a heterogeneous (mixed) collection of geometries.
 type GeometryCollection struct {
 	Geometry   []GeometrySubtype
 	Dimensions Dimensions
+
+	// SRIDs embedded in the EWKB of members, by index of the member. PostGIS
+	// does not embed SRIDs in members, but those read are kept and written.
+	MemberSRIDs map[int]uint32
 }
 
 func (g GeometryCollection) GetGISGeometryType() GISGeometryType {
@@ -36,11 +40,41 @@ func (g GeometryCollection) String() string {
 	return sb.String()
 }
 
+// Report whether the GeometryCollection equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (g GeometryCollection) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&g, other, o.Tolerance)
+	}
+	h, ok := subtypeAs[GeometryCollection](other)
+	if !ok || g.Dimensions != h.Dimensions || !membersEqual(g.Geometry, h.Geometry, o) {
+		return false
+	}
+	if o.IgnoreSRID {
+		return true
+	}
+	if len(g.MemberSRIDs) != len(h.MemberSRIDs) {
+		return false
+	}
+	for i, srid := range g.MemberSRIDs {
+		if s, ok := h.MemberSRIDs[i]; !ok || s != srid {
+			return false
+		}
+	}
+	return true
+}
+
 // Get the dimensions of the geometry
 func (c GeometryCollection) GetDimensions() Dimensions {
 	return c.Dimensions
 }
 
+// Get the bounding box of the GeometryCollection in each of its dimensions
+func (c GeometryCollection) Envelope() Envelope {
+	return membersEnvelope(c.Geometry, c.Dimensions)
+}
+
 // Create a new GeometryCollection from input slice of GeometrySubTypes
 // Geometry slice must be at least length 1
 func NewGeometryCollection(g []GeometrySubtype) (*GeometryCollection, error) {
@@ -55,104 +89,132 @@ func NewGeometryCollection(g []GeometrySubtype) (*GeometryCollection, error) {
 	return &gc, nil
 }
 
-// Create a new GeometryCollection from input byte buffer in EWKB format and dimensions.
-func GeometryCollectionFromEWKB(b *bytes.Buffer, dimensions Dimensions) (*GeometryCollection, error) {
+// Create a new GeometryCollection from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func GeometryCollectionFromEWKB(b *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*GeometryCollection, error) {
+	d, err := newDecodeState(pathName(GeometryCollectionType), b.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return geometryCollectionFromEWKB(b, dimensions, d)
+}
+
+func geometryCollectionFromEWKB(b *bytes.Buffer, dimensions Dimensions, d *decodeState) (*GeometryCollection, error) {
 
 	geometryCollection := GeometryCollection{}
 	geometryCollection.Dimensions = dimensions
-	if b.Len() < 4 {
-		return nil, fmt.Errorf("byte array for geometrycollection must be at least length 4")
+	count, err := d.readCount(b, "geometrycollection")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.enter(b); err != nil {
+		return nil, err
 	}
-	count := binary.LittleEndian.Uint32(b.Next(4))
+	defer d.leave()
 
 	for i := 0; i < int(count); i++ {
-
-		b.Next(1) // move past BOM
-		geoTypeBytes := b.Next(4)
-		geoType := GISGeometryType(binary.LittleEndian.Uint16(geoTypeBytes)) // 4 bytes for geometry type
-		flags := geoTypeBytes[3]                                             // flags are stored on the 3rd byte
-
-		//var SRID uint16
-		if flags&byte(wkbSRID) == byte(wkbSRID) {
-			_ = binary.LittleEndian.Uint16(b.Next(4))
+		d.at(i)
+		// Read the BOM, geometry type and any SRID of the member
+		header, err := d.readMemberHeader(b, "geometrycollection", dimensions)
+		if err != nil {
+			return nil, err
+		}
+		geoType, memberDimensions := header.geoType, header.dimensions
+		if header.sridFlag {
+			if geometryCollection.MemberSRIDs == nil {
+				geometryCollection.MemberSRIDs = map[int]uint32{}
+			}
+			geometryCollection.MemberSRIDs[i] = header.srid
 		}
+		d.push(pathName(geoType))
 
 		var geometrySubType GeometrySubtype
-		var err error
 
 		switch geoType {
 		case PointType:
-			geometrySubType, err = PointFromEWKB(b, dimensions)
+			geometrySubType, err = pointFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case LineStringType:
-			geometrySubType, err = LineStringFromEWKB(b, dimensions)
+			geometrySubType, err = lineStringFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case PolygonType:
-			geometrySubType, err = PolygonFromEWKB(b, dimensions)
+			geometrySubType, err = polygonFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case MultiPointType:
-			geometrySubType, err = MultiPointFromEWKB(b, dimensions)
+			geometrySubType, err = multiPointFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case MultiLineStringType:
-			geometrySubType, err = MultiLineStringFromEWKB(b, dimensions)
+			geometrySubType, err = multiLineStringFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case MultiPolygonType:
-			geometrySubType, err = MultiPolygonFromEWKB(b, dimensions)
+			geometrySubType, err = multiPolygonFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case CircularStringType:
-			geometrySubType, err = CircularStringFromEWKB(b, dimensions)
+			geometrySubType, err = circularStringFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case CompoundCurveType:
-			geometrySubType, err = CompoundCurveFromEWKB(b, dimensions)
+			geometrySubType, err = compoundCurveFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case CurvePolygonType:
-			geometrySubType, err = CurvePolygonFromEWKB(b, dimensions)
+			geometrySubType, err = curvePolygonFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case MultiCurveType:
-			geometrySubType, err = MultiCurveFromEWKB(b, dimensions)
+			geometrySubType, err = multiCurveFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case MultiSurfaceType:
-			geometrySubType, err = MultiSurfaceFromEWKB(b, dimensions)
+			geometrySubType, err = multiSurfaceFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case PolyHedralSurfaceType:
-			geometrySubType, err = PolyhedralSurfaceFromEWKB(b, dimensions)
+			geometrySubType, err = polyhedralSurfaceFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case TINType:
-			geometrySubType, err = TINFromEWKB(b, dimensions)
+			geometrySubType, err = tinFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case TriangleType:
-			geometrySubType, err = TriangleFromEWKB(b, dimensions)
+			geometrySubType, err = triangleFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
+		case GeometryCollectionType:
+			geometrySubType, err = geometryCollectionFromEWKB(b, memberDimensions, d)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			d.pop()
+			err := d.errorAt(header.offset, fmt.Errorf("unknown geometry type in geometrycollection: %v", geoType))
+			err.Actual = geoType
+			return nil, err
 		}
 
+		forceDimensions(geometrySubType, dimensions)
+		d.pop()
 		geometryCollection.Geometry = append(geometryCollection.Geometry, geometrySubType)
 	}
 
@@ -174,10 +236,61 @@ func (gc GeometryCollection) GetEWKB(includeGeoType bool) bytes.Buffer {
 	lenBytes := binary.LittleEndian.AppendUint32([]byte{}, uint32(len(gc.Geometry)))
 	buf.Write(lenBytes)
 
-	// Add encoded geometry
-	for _, g := range gc.Geometry {
-		gb := g.GetEWKB(true)
+	// Add encoded geometry, with the embedded SRID of any member that has one
+	for i, g := range gc.Geometry {
+		srid, ok := gc.MemberSRIDs[i]
+		if !ok {
+			gb := g.GetEWKB(true)
+			gb.WriteTo(buf)
+			continue
+		}
+		buf.WriteByte(byte(LittleEndian))
+		buf.Write(encodeGeoType(g.GetGISGeometryType(), true, g.GetDimensions()))
+		buf.Write(binary.LittleEndian.AppendUint32([]byte{}, srid))
+		gb := g.GetEWKB(false)
 		gb.WriteTo(buf)
 	}
 	return *buf
 }
+
+// Report whether the GeometryCollection is valid, as ST_IsValid
+func (gc GeometryCollection) IsValid() bool {
+	return gc.Validate() == nil
+}
+
+// Check the members of the GeometryCollection are valid
+func (gc GeometryCollection) Validate() error {
+	return validityError(validateMembers(gc.Geometry, gc.Dimensions), pathName(GeometryCollectionType))
+}
+
+// Get the area of the GeometryCollection, the sum of those of its members
+func (c GeometryCollection) Area() float64 {
+	total := 0.0
+	for _, member := range c.Geometry {
+		total += member.Area()
+	}
+	return total
+}
+
+// Get the length of the GeometryCollection, the sum of those of its members
+func (c GeometryCollection) Length() float64 {
+	total := 0.0
+	for _, member := range c.Geometry {
+		total += member.Length()
+	}
+	return total
+}
+
+// Get the perimeter of the GeometryCollection, the sum of those of its members
+func (c GeometryCollection) Perimeter() float64 {
+	total := 0.0
+	for _, member := range c.Geometry {
+		total += member.Perimeter()
+	}
+	return total
+}
+
+// Get the minimum distance between the GeometryCollection and another geometry, as ST_Distance
+func (c GeometryCollection) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&c, other)
+}
@@ -1,6 +1,7 @@
 package geo_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -29,6 +30,38 @@ func TestGeometryCollection(t *testing.T) {
 
 }
 
+func TestGeometryCollectionMemberSRIDs(t *testing.T) {
+	point := makeTestPoint(t, geo.XYZ)
+	lineString := makeTestLineString(t, 3, geo.XYZ)
+	geometryCollection, err := geo.NewGeometryCollection([]geo.GeometrySubtype{point, lineString})
+	if err != nil {
+		t.Fatal(err)
+	}
+	geometryCollection.MemberSRIDs = map[int]uint32{1: 4326}
+
+	ewkb := geometryCollection.GetEWKB(false)
+	encoded := ewkb.Bytes()
+
+	// The second member carries the SRID flag and its SRID
+	pointLength := 1 + 4 + 3*8
+	if flags := encoded[4+pointLength+4]; flags != 0x80|0x20 {
+		t.Errorf("expected member flags %x, got %x", 0x80|0x20, flags)
+	}
+
+	geometryCollection2, err := geo.GeometryCollectionFromEWKB(bytes.NewBuffer(encoded), geo.XYZ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(geometryCollection, geometryCollection2) {
+		t.Errorf("geometrycollection %v was not equal to geometrycollection %v", geometryCollection, geometryCollection2)
+	}
+
+	reencoded := geometryCollection2.GetEWKB(false)
+	if !bytes.Equal(reencoded.Bytes(), encoded) {
+		t.Errorf("geometrycollection was re-encoded as %x, expected %x", reencoded.Bytes(), encoded)
+	}
+}
+
 func makeTestGeometryCollection(t *testing.T) *geo.GeometryCollection {
 	dims := geo.XY
 
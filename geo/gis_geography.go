@@ -0,0 +1,163 @@
+package geo
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sync"
+)
+
+/*
+	https://postgis.net/docs/using_postgis_dbmanagement.html#PostGIS_Geography
+
+A GISGeography is a GISGeometry stored in a PostGIS geography column. Geography
+coordinates are longitude/latitude on a geodetic spatial reference system, and
+PostGIS measures distances and areas between them in metres on the spheroid.
+
+Geography uses the same EWKB encoding as geometry, but always carries an SRID,
+which must be accepted by GeodeticSRID, and coordinates must lie within
+longitude [-180, 180] and latitude [-90, 90], as checked by ValidateGeodetic.
+*/
+type GISGeography struct {
+	GISGeometry
+}
+
+// Geodetic spatial reference systems accepted for geography, keyed by SRID.
+// Others are added with RegisterGeodeticSRID.
+var (
+	geodeticMu    sync.RWMutex
+	geodeticSRIDs = map[uint32]string{
+		4326: "WGS 84",
+		4979: "WGS 84 (3D)",
+		4269: "NAD83",
+		4258: "ETRS89",
+		4283: "GDA94",
+		7844: "GDA2020",
+		4167: "NZGD2000",
+	}
+)
+
+// Get the name of a geodetic spatial reference system accepted for geography,
+// and whether the SRID is accepted
+func GeodeticSRID(srid uint32) (string, bool) {
+	geodeticMu.RLock()
+	defer geodeticMu.RUnlock()
+	name, ok := geodeticSRIDs[srid]
+	return name, ok
+}
+
+// Accept a further longitude/latitude spatial reference system for geography,
+// such as any other geodetic SRID in the spatial_ref_sys table of the database
+func RegisterGeodeticSRID(srid uint32, name string) {
+	geodeticMu.Lock()
+	defer geodeticMu.Unlock()
+	geodeticSRIDs[srid] = name
+}
+
+// Create a new GISGeography in the default SRID (4326 - WGS 84) from a geometry
+// with longitude/latitude coordinates.
+func NewGISGeography(geo GeometrySubtype) (GISGeography, error) {
+	g := GISGeography{GISGeometry: NewGISGeometry(geo)}
+	g.SetSRID(defaultSRID)
+	if err := g.ValidateGeodetic(); err != nil {
+		return GISGeography{}, err
+	}
+	return g, nil
+}
+
+// Check the SRID is geodetic and all coordinates are valid longitude/latitude.
+// Validate and IsValid check the validity of the geometry, as for GISGeometry.
+func (g GISGeography) ValidateGeodetic() error {
+	if _, ok := GeodeticSRID(g.SRID); !ok {
+		return fmt.Errorf("srid %v is not a geodetic spatial reference system", g.SRID)
+	}
+	if g.Geometry == nil {
+		return fmt.Errorf("geography has no geometry")
+	}
+
+	view, err := NewEWKBView(g.encodeEWKB())
+	if err != nil {
+		return err
+	}
+	return validateLonLat(view)
+}
+
+// Check all coordinates of the view lie within longitude/latitude ranges
+func validateLonLat(view EWKBView) error {
+	var invalid []float64
+	err := view.EachCoord(func(coord []float64) bool {
+		lon, lat := coord[0], coord[1]
+		if math.IsNaN(lon) && math.IsNaN(lat) {
+			return true // empty point
+		}
+		if !(lon >= -180 && lon <= 180 && lat >= -90 && lat <= 90) {
+			invalid = append(invalid, lon, lat)
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if invalid != nil {
+		return fmt.Errorf("coordinate (%v %v) is outside longitude [-180, 180] / latitude [-90, 90]", invalid[0], invalid[1])
+	}
+	return nil
+}
+
+// Used to generate a database/sql/driver.Value to write
+func (g GISGeography) Value() (driver.Value, error) {
+	if err := g.ValidateGeodetic(); err != nil {
+		return nil, err
+	}
+	return g.GISGeometry.Value()
+}
+
+// Used to map GISGeography values into structs when read by the database driver
+func (g *GISGeography) Scan(value interface{}) error {
+	hexewkb, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("scan expected []byte, got %T (%v)", value, value)
+	}
+	if err := checkHexSize(hexewkb, DefaultDecodeOptions); err != nil {
+		return err
+	}
+
+	ewkb := make([]byte, hex.DecodedLen(len(hexewkb)))
+	_, err := hex.Decode(ewkb, hexewkb)
+	if err != nil {
+		return err
+	}
+
+	return g.UnmarshalBinary(ewkb)
+}
+
+// Get the binary (not hex encoded) EWKB representation of the GISGeography,
+// implementing encoding.BinaryMarshaler
+func (g GISGeography) MarshalBinary() ([]byte, error) {
+	if err := g.ValidateGeodetic(); err != nil {
+		return nil, err
+	}
+	return g.encodeEWKB(), nil
+}
+
+// Populate the GISGeography from binary (not hex encoded) EWKB,
+// implementing encoding.BinaryUnmarshaler
+func (g *GISGeography) UnmarshalBinary(ewkb []byte) error {
+	return g.DecodeEWKB(ewkb, DefaultDecodeOptions)
+}
+
+// Populate the GISGeography from binary (not hex encoded) EWKB, within the
+// limits of the DecodeOptions
+func (g *GISGeography) DecodeEWKB(ewkb []byte, opts DecodeOptions) error {
+	if err := g.decodeEWKB(ewkb, opts); err != nil {
+		return err
+	}
+
+	// Geography without an embedded SRID is in the default SRID
+	if !g.SRIDFlag {
+		g.SetSRID(defaultSRID)
+	}
+	return g.ValidateGeodetic()
+}
@@ -0,0 +1,109 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestGISGeography(t *testing.T) {
+
+	lineString, err := geo.LineStringFromCoords([]float64{-0.1276, 51.5072, 2.3522, 48.8566}, geo.XY)
+	if err != nil {
+		t.Error(err)
+	}
+
+	geography, err := geo.NewGISGeography(lineString)
+	if err != nil {
+		t.Error(err)
+	}
+	if geography.SRID != 4326 {
+		t.Errorf("geography srid %v was not 4326", geography.SRID)
+	}
+
+	value, err := geography.Value()
+	if err != nil {
+		t.Error(err)
+	}
+
+	geography2 := geo.GISGeography{}
+	err = geography2.Scan(value)
+	if err != nil {
+		t.Error(err)
+	}
+	if !cmp.Equal(geography, geography2) {
+		t.Errorf("geography %v was not equal to %v", geography2, geography)
+	}
+
+	// Projected SRIDs are not valid for geography
+	geography.SetSRID(27700)
+	_, err = geography.Value()
+	if err == nil {
+		t.Error("geography with projected srid did not return an error")
+	}
+}
+
+func TestGISGeographyRange(t *testing.T) {
+
+	point, err := geo.NewPoint([]float64{181, 45}, geo.XY)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = geo.NewGISGeography(point)
+	if err == nil {
+		t.Error("geography with longitude 181 did not return an error")
+	}
+
+	point, err = geo.NewPoint([]float64{10, -90.5}, geo.XY)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = geo.NewGISGeography(point)
+	if err == nil {
+		t.Error("geography with latitude -90.5 did not return an error")
+	}
+
+	// Out of range geometry scanned from a geography column is rejected
+	gisGeometry := geo.NewGISGeometry(point)
+	gisGeometry.SetSRID(4326)
+	value, err := gisGeometry.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	geography := geo.GISGeography{}
+	err = geography.Scan(value)
+	if err == nil {
+		t.Error("scanned geography with latitude -90.5 did not return an error")
+	}
+}
+
+func TestGISGeographyValidity(t *testing.T) {
+
+	if name, ok := geo.GeodeticSRID(4326); !ok || name != "WGS 84" {
+		t.Errorf("srid 4326 was %q, %v, expected WGS 84", name, ok)
+	}
+	if _, ok := geo.GeodeticSRID(27700); ok {
+		t.Error("projected srid 27700 was accepted as geodetic")
+	}
+	geo.RegisterGeodeticSRID(4148, "Hartebeesthoek94")
+	if name, ok := geo.GeodeticSRID(4148); !ok || name != "Hartebeesthoek94" {
+		t.Errorf("registered srid 4148 was %q, %v", name, ok)
+	}
+
+	// A self-intersecting polygon is a valid geography but not a valid geometry
+	ring, err := geo.LinearRingFromCoords([]float64{0, 0, 1, 1, 1, 0, 0, 1, 0, 0}, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	geography, err := geo.NewGISGeography(&geo.Polygon{LinearRings: []geo.LinearRing{*ring}, Dimensions: geo.XY})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := geography.ValidateGeodetic(); err != nil {
+		t.Errorf("geography was not geodetic: %v", err)
+	}
+	if err := geography.Validate(); err == nil || geography.IsValid() {
+		t.Errorf("self-intersecting geography was valid: %v, %v", err, geography.IsValid())
+	}
+}
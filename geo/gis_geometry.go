@@ -44,11 +44,42 @@ func (c Dimensions) String() string {
 	}
 }
 
+// Returns the number of float64 ordinates stored for each point of the given dimensions
+func (c Dimensions) Stride() int {
+	switch c {
+	case XY:
+		return 2
+	case XYZ, XYM:
+		return 3
+	case XYZM:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Report whether points of the given dimensions have a Z ordinate
+func (c Dimensions) HasZ() bool {
+	return c == XYZ || c == XYZM
+}
+
+// Report whether points of the given dimensions have an M ordinate, which is always last
+func (c Dimensions) HasM() bool {
+	return c == XYM || c == XYZM
+}
+
 type GeometrySubtype interface {
 	GetEWKB(bool) bytes.Buffer
 	GetDimensions() Dimensions
 	GetGISGeometryType() GISGeometryType
 	String() string
+	Validate() error
+	Equals(other GeometrySubtype, opts ...EqualOptions) bool
+	Envelope() Envelope
+	Area() float64
+	Length() float64
+	Perimeter() float64
+	Distance(other GeometrySubtype) (float64, error)
 }
 
 type GISGeometry struct {
@@ -301,6 +332,18 @@ func decodeGeotype(bytes []byte) (geoType GISGeometryType, SRIDFlag bool, dimens
 
 // Used to generate a database/sql/driver.Value to write
 func (g GISGeometry) Value() (driver.Value, error) {
+	ewkb := g.encodeEWKB()
+
+	// Encode and return HEX EWKB data
+	hexewkb := make([]byte, hex.EncodedLen(len(ewkb)))
+	hex.Encode(hexewkb, ewkb)
+
+	return hexewkb, nil
+
+}
+
+// Get the binary (not hex encoded) EWKB representation of the GISGeometry
+func (g GISGeometry) encodeEWKB() []byte {
 	ewkb := []byte{} // byte array to hold EWKB data
 
 	ewkb = append(ewkb, byte(defaultByteOrder)) // Byte Order Marker
@@ -317,12 +360,7 @@ func (g GISGeometry) Value() (driver.Value, error) {
 	geometry := g.Geometry.GetEWKB(false)
 	ewkb = append(ewkb, geometry.Bytes()...)
 
-	// Encode and return HEX EWKB data
-	hexewkb := make([]byte, hex.EncodedLen(len(ewkb)))
-	hex.Encode(hexewkb, ewkb)
-
-	return hexewkb, nil
-
+	return ewkb
 }
 
 // Used to map GISGeometry values into structs when read by the database driver
@@ -334,6 +372,9 @@ func (g *GISGeometry) Scan(value interface{}) error {
 	if !ok {
 		return fmt.Errorf("scan expected []byte, got %T (%v)", value, value)
 	}
+	if err := checkHexSize(hexewkb, DefaultDecodeOptions); err != nil {
+		return err
+	}
 
 	// Decode into EWKB byte array
 	ewkb := make([]byte, hex.DecodedLen(len(hexewkb)))
@@ -342,16 +383,50 @@ func (g *GISGeometry) Scan(value interface{}) error {
 		return err
 	}
 
-	if len(ewkb) < 9 {
-		return fmt.Errorf("ewkb must be at least 9 bytes to contain byte order, type, and srid")
+	return g.decodeEWKB(ewkb)
+}
+
+// Get the binary (not hex encoded) EWKB representation of the GISGeometry,
+// implementing encoding.BinaryMarshaler
+func (g GISGeometry) MarshalBinary() ([]byte, error) {
+	return g.encodeEWKB(), nil
+}
+
+// Populate the GISGeometry from binary (not hex encoded) EWKB,
+// implementing encoding.BinaryUnmarshaler
+func (g *GISGeometry) UnmarshalBinary(ewkb []byte) error {
+	return g.decodeEWKB(ewkb)
+}
+
+// Populate the GISGeometry from binary (not hex encoded) EWKB, within the
+// limits of the DecodeOptions
+func (g *GISGeometry) DecodeEWKB(ewkb []byte, opts DecodeOptions) error {
+	return g.decodeEWKB(ewkb, opts)
+}
+
+// Check the size of hex encoded EWKB against MaxInputSize before it is decoded
+func checkHexSize(hexewkb []byte, opts DecodeOptions) error {
+	return checkLimit("MaxInputSize", opts.withDefaults().MaxInputSize, int64(hex.DecodedLen(len(hexewkb))))
+}
+
+// Populate the GISGeometry from a binary (not hex encoded) EWKB byte slice,
+// using the DefaultDecodeOptions if no options are given
+func (g *GISGeometry) decodeEWKB(ewkb []byte, opts ...DecodeOptions) error {
+
+	d, err := newDecodeState("", len(ewkb), opts)
+	if err != nil {
+		return err
 	}
 
 	buffer := bytes.NewBuffer(ewkb)
+	if len(ewkb) < 9 {
+		return d.errorf(buffer, "ewkb must be at least 9 bytes to contain byte order, type, and srid")
+	}
 
 	//The first byte indicates the byte order. 00 for big endian, or 01 for little endian.
 	bom, err := buffer.ReadByte()
 	if err != nil {
-		return fmt.Errorf("unable to read byte order marker")
+		return d.errorf(buffer, "unable to read byte order marker")
 	}
 	g.ByteOrder = ByteOrder(bom)
 
@@ -362,111 +437,125 @@ func (g *GISGeometry) Scan(value interface{}) error {
 		// Get the SRID if present
 		if g.SRIDFlag {
 			g.SRID = binary.LittleEndian.Uint32(buffer.Next(4))
+			d.srid, d.sridKnown = g.SRID, true
 		}
 
+		d.push(pathName(g.GeoType))
+
 		// Get the geometry from the remaining data
 		var geometry GeometrySubtype
 
 		switch g.GeoType {
 		case PointType:
-			geometry, err = PointFromEWKB(buffer, g.Dimensions)
+			geometry, err = pointFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
-				return fmt.Errorf(err.Error())
+				return err
 			}
 
 		case LineStringType:
-			geometry, err = LineStringFromEWKB(buffer, g.Dimensions)
+			geometry, err = lineStringFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
-				return fmt.Errorf(err.Error())
+				return err
 			}
 
 		case PolygonType:
-			geometry, err = PolygonFromEWKB(buffer, g.Dimensions)
+			geometry, err = polygonFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		case MultiPointType:
-			geometry, err = MultiPointFromEWKB(buffer, g.Dimensions)
+			geometry, err = multiPointFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		case MultiLineStringType:
-			geometry, err = MultiLineStringFromEWKB(buffer, g.Dimensions)
+			geometry, err = multiLineStringFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		case MultiPolygonType:
-			geometry, err = MultiPolygonFromEWKB(buffer, g.Dimensions)
+			geometry, err = multiPolygonFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		case GeometryCollectionType:
-			geometry, err = GeometryCollectionFromEWKB(buffer, g.Dimensions)
+			geometry, err = geometryCollectionFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		case CircularStringType:
-			geometry, err = CircularStringFromEWKB(buffer, g.Dimensions)
+			geometry, err = circularStringFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		case CompoundCurveType:
-			geometry, err = CompoundCurveFromEWKB(buffer, g.Dimensions)
+			geometry, err = compoundCurveFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		case CurvePolygonType:
-			geometry, err = CurvePolygonFromEWKB(buffer, g.Dimensions)
+			geometry, err = curvePolygonFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		case MultiCurveType:
-			geometry, err = MultiCurveFromEWKB(buffer, g.Dimensions)
+			geometry, err = multiCurveFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		case MultiSurfaceType:
-			geometry, err = MultiSurfaceFromEWKB(buffer, g.Dimensions)
+			geometry, err = multiSurfaceFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		case PolyHedralSurfaceType:
-			geometry, err = PolyhedralSurfaceFromEWKB(buffer, g.Dimensions)
+			geometry, err = polyhedralSurfaceFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		case TINType:
-			geometry, err = TINFromEWKB(buffer, g.Dimensions)
+			geometry, err = tinFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		case TriangleType:
-			geometry, err = TriangleFromEWKB(buffer, g.Dimensions)
+			geometry, err = triangleFromEWKB(buffer, g.Dimensions, d)
 			if err != nil {
 				return err
 			}
 
 		default:
-			return fmt.Errorf("unknown geometry type: %v", g.GeoType)
+			err := d.errorAt(1, fmt.Errorf("unknown geometry type: %v", g.GeoType))
+			err.Actual = g.GeoType
+			return err
 
 		}
 
 		g.Geometry = geometry
 
+		if buffer.Len() > 0 {
+			switch d.opts.Mode {
+			case DecodeStrict:
+				return d.errorf(buffer, "%v bytes follow the geometry", buffer.Len())
+			case DecodeLenient:
+				d.warn(d.offset(buffer), "ignored %v bytes following the geometry", buffer.Len())
+			}
+		}
+
 	} else {
-		return fmt.Errorf("big endian is currently unsupported")
+		return d.errorAt(0, fmt.Errorf("big endian is currently unsupported"))
 
 	}
 
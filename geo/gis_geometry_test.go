@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	_ "github.com/lib/pq"
+	"github.com/stephenirven/go-postgis/db/fakedb"
 	db "github.com/stephenirven/go-postgis/db/sqlc"
 	"github.com/stephenirven/go-postgis/geo"
 )
@@ -18,12 +19,25 @@ const (
 )
 
 var testQueries *db.Queries
+var testDB *sql.DB
+
+// Whether the tests are running against PostGIS, rather than the in-memory fake
+var testPostGIS bool
 
 func init() {
-	testDB, err := sql.Open(dbDriver, dbSource)
+	var err error
+	testDB, err = sql.Open(dbDriver, dbSource)
 	if err != nil {
 		log.Fatal("failed to connect to database", err)
 	}
+	testPostGIS = testDB.Ping() == nil
+	if !testPostGIS {
+		testDB.Close()
+		testDB, err = fakedb.Open("geo")
+		if err != nil {
+			log.Fatal("failed to open fake database", err)
+		}
+	}
 	testQueries = db.New(testDB)
 }
 func TestGISPointGeometry(t *testing.T) {
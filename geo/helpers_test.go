@@ -0,0 +1,33 @@
+package geo_test
+
+import "github.com/stephenirven/go-postgis/geo"
+
+// Create an XY ring of the coordinates
+func xyRing(coords ...float64) geo.LinearRing {
+	return geo.LinearRing{Coords: coords, Dimensions: geo.XY}
+}
+
+// Create an anticlockwise XY ring of a square with its lower left corner at x, y
+func xySquare(x, y, size float64) geo.LinearRing {
+	return xyRing(x, y, x+size, y, x+size, y+size, x, y+size, x, y)
+}
+
+// Create an XY polygon of the rings
+func xyPolygon(rings ...geo.LinearRing) *geo.Polygon {
+	return &geo.Polygon{LinearRings: rings, Dimensions: geo.XY}
+}
+
+// Create an XY point
+func xyPoint(x, y float64) *geo.Point {
+	return &geo.Point{Coords: []float64{x, y}, Dimensions: geo.XY}
+}
+
+// Create an XY linestring of the coordinates
+func xyLineString(coords ...float64) *geo.LineString {
+	return &geo.LineString{Coords: coords, Dimensions: geo.XY}
+}
+
+// Create an XY circularstring of the coordinates
+func xyCircularString(coords ...float64) *geo.CircularString {
+	return &geo.CircularString{Coords: coords, Dimensions: geo.XY}
+}
@@ -19,7 +19,7 @@ A LineString is closed if the start and end points are the same. A LineString
 is simple if it does not self-intersect.
 */
 type LineString struct {
-	Points     []Point
+	Coords     []float64
 	Dimensions Dimensions
 }
 
@@ -33,30 +33,76 @@ func (l LineString) String() string {
 	sb.WriteString("(LineString ")
 	sb.WriteString(l.Dimensions.String())
 	sb.WriteString(" [")
-	for _, p := range l.Points {
-		sb.WriteString(p.String())
-		sb.WriteString(" ")
-	}
+	writeCoordsString(&sb, l.Coords, l.Dimensions)
 
 	sb.WriteString("])")
 	return sb.String()
 }
 
+// Report whether the LineString equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (l LineString) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&l, other, o.Tolerance)
+	}
+	m, ok := subtypeAs[LineString](other)
+	return ok && l.Dimensions == m.Dimensions && sequencesEqual(l.Coords, m.Coords, l.Dimensions, 1, o)
+}
+
 // Get the dimensions of the geometry
 func (l LineString) GetDimensions() Dimensions {
 	return l.Dimensions
 }
 
+// Get the bounding box of the LineString in each of its dimensions
+func (l LineString) Envelope() Envelope {
+	return coordsEnvelope(l.Coords, l.Dimensions)
+}
+
+// Get the number of points in the LineString
+func (l LineString) NumPoints() int {
+	return coordCount(l.Coords, l.Dimensions)
+}
+
+// Get the ordinates of the point at index i. The slice shares storage with the LineString.
+func (l LineString) Coord(i int) []float64 {
+	return coordAt(l.Coords, l.Dimensions, i)
+}
+
+// Get the point at index i. The point shares storage with the LineString.
+func (l LineString) PointN(i int) Point {
+	return Point{Coords: l.Coord(i), Dimensions: l.Dimensions}
+}
+
+// Get the points of the LineString, each sharing storage with it
+func (l LineString) Points() []Point {
+	return pointsFromCoords(l.Coords, l.Dimensions)
+}
+
 // Create a LineString from a slice of Points of the same dimensions.
 // Length must be at least 1
 func NewLineString(p []Point) (*LineString, error) {
-	l := LineString{}
-	l.Points = p
 	if len(p) == 0 {
 		return nil, fmt.Errorf("error creating linestring, no points provided")
 	}
-	l.Dimensions = p[0].GetDimensions()
-	return &l, nil
+	coords, err := flattenPoints(p, p[0].GetDimensions())
+	if err != nil {
+		return nil, fmt.Errorf("error creating linestring, %v", err)
+	}
+	return LineStringFromCoords(coords, p[0].GetDimensions())
+}
+
+// Create a LineString from a flat slice of ordinates and Dimensions.
+// Length must be at least 1 point, and a whole number of points.
+func LineStringFromCoords(coords []float64, dimensions Dimensions) (*LineString, error) {
+	if err := checkCoords(coords, dimensions); err != nil {
+		return nil, fmt.Errorf("error creating linestring, %v", err)
+	}
+	if len(coords) == 0 {
+		return nil, fmt.Errorf("error creating linestring, no points provided")
+	}
+	return &LineString{Coords: coords, Dimensions: dimensions}, nil
 }
 
 // Returns the expected byte length for a LineString of given dimensions and length
@@ -64,27 +110,37 @@ func LineStringByteLength(dimensions Dimensions, length uint32) uint32 {
 	return PointByteLength(dimensions) * length
 }
 
-// Create a new Linestring from input byte buffer in EWKB format and dimensions.
-func LineStringFromEWKB(b *bytes.Buffer, dimensions Dimensions) (*LineString, error) {
+// Create a new Linestring from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func LineStringFromEWKB(b *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*LineString, error) {
+	d, err := newDecodeState(pathName(LineStringType), b.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return lineStringFromEWKB(b, dimensions, d)
+}
+
+func lineStringFromEWKB(b *bytes.Buffer, dimensions Dimensions, d *decodeState) (*LineString, error) {
 
 	ls := LineString{}
 	ls.Dimensions = dimensions
 
 	// Get the length of the LineString
-	count := binary.LittleEndian.Uint32(b.Next(4))
+	count, err := d.readCount(b, "linestring")
+	if err != nil {
+		return nil, err
+	}
 
-	if uint32(b.Len()) < LineStringByteLength(dimensions, count) {
-		return nil, fmt.Errorf("input for linestring is too short (%v) for requested length %v, dimensions %v", b.Len(), count, dimensions)
+	if d.opts.Mode == DecodeStrict && count == 1 {
+		return nil, d.errorAt(d.offset(b)-4, fmt.Errorf("linestring must not have exactly one point"))
 	}
 
 	// Add point data for requested line
-	for i := 0; i < int(count); i++ {
-		point, err := PointFromEWKB(b, dimensions)
-		if err != nil {
-			return nil, fmt.Errorf(err.Error())
-		}
-		ls.Points = append(ls.Points, *point)
+	coords, err := d.readCoords(b, dimensions, count)
+	if err != nil {
+		return nil, err
 	}
+	ls.Coords = coords
 
 	return &ls, nil
 }
@@ -101,14 +157,51 @@ func (l LineString) GetEWKB(includeGeoType bool) bytes.Buffer {
 	}
 
 	// Encode the length
-	lenBytes := binary.LittleEndian.AppendUint32([]byte{}, uint32(len(l.Points)))
+	lenBytes := binary.LittleEndian.AppendUint32([]byte{}, uint32(l.NumPoints()))
 
 	buf.Write(lenBytes)
 
-	for _, p := range l.Points {
-		//no BOM or geotype
-		qb := p.GetEWKB(false)
-		qb.WriteTo(buf)
-	}
+	//no BOM or geotype
+	writeCoords(buf, l.Coords)
 	return *buf
 }
+
+// Report whether the LineString is valid, as ST_IsValid
+func (l LineString) IsValid() bool {
+	return l.Validate() == nil
+}
+
+// Check the LineString has finite ordinates, and is empty or has at least 2 distinct points
+func (l LineString) Validate() error {
+	return validityError(l.validate(), pathName(LineStringType))
+}
+
+func (l LineString) validate() *ValidityError {
+	if e := validateCoords(l.Coords, l.Dimensions, false); e != nil {
+		return e
+	}
+	if l.NumPoints() > 0 && len(distinctXY(l.Coords, l.Dimensions)) < 2 {
+		return invalid("Too few points in geometry component", l.Coord(0))
+	}
+	return nil
+}
+
+// Get the area of the LineString, which has none
+func (l LineString) Area() float64 {
+	return 0
+}
+
+// Get the length of the LineString, as ST_Length
+func (l LineString) Length() float64 {
+	return polylineLength(l.Coords, l.Dimensions)
+}
+
+// Get the perimeter of the LineString, which has none
+func (l LineString) Perimeter() float64 {
+	return 0
+}
+
+// Get the minimum distance between the LineString and another geometry, as ST_Distance
+func (l LineString) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&l, other)
+}
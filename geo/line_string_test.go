@@ -45,3 +45,81 @@ func makeTestLineString(t *testing.T, length int, dimensions geo.Dimensions) *ge
 	return lineString
 
 }
+
+func TestLineStringCoords(t *testing.T) {
+
+	dims := geo.XYZ
+
+	l := makeTestLineString(t, 10, dims)
+
+	if l.NumPoints() != 10 {
+		t.Errorf("linestring has %v points, expected 10", l.NumPoints())
+	}
+	if len(l.Coords) != 10*dims.Stride() {
+		t.Errorf("linestring has %v ordinates, expected %v", len(l.Coords), 10*dims.Stride())
+	}
+
+	// Points from the compatibility accessor rebuild an identical linestring
+	m, err := geo.NewLineString(l.Points())
+	if err != nil {
+		t.Error(err)
+	}
+	if !cmp.Equal(l, m) {
+		t.Errorf("linestring %v was not equal to linestring %v", l, m)
+	}
+
+	for i := 0; i < l.NumPoints(); i++ {
+		if !cmp.Equal(l.PointN(i).Coords, l.Coords[i*3:i*3+3]) {
+			t.Errorf("point %v %v did not match ordinates %v", i, l.PointN(i), l.Coords[i*3:i*3+3])
+		}
+	}
+
+	_, err = geo.LineStringFromCoords([]float64{1, 2, 3, 4}, dims)
+	if err == nil {
+		t.Error("linestring created from partial point ordinates")
+	}
+
+	_, err = geo.NewLineString([]geo.Point{*makeTestPoint(t, geo.XY), *makeTestPoint(t, geo.XYZ)})
+	if err == nil {
+		t.Error("linestring created from points of mixed dimensions")
+	}
+}
+
+func TestPointsCompatibility(t *testing.T) {
+
+	points := []geo.Point{
+		{Coords: []float64{0, 0, 1}, Dimensions: geo.XYZ},
+		{Coords: []float64{1, 0, 2}, Dimensions: geo.XYZ},
+		{Coords: []float64{1, 1, 3}, Dimensions: geo.XYZ},
+		{Coords: []float64{0, 0, 1}, Dimensions: geo.XYZ},
+	}
+
+	// Geometries built from Points give back the same Points
+	ls, err := geo.NewLineString(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lr, err := geo.NewLinearRing(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs, err := geo.NewCircularString(points[:3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	mp, err := geo.NewMultiPoint(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := geo.NewTriangle([4]geo.Point(points))
+	if err != nil {
+		t.Fatal(err)
+	}
+	triangle := tr.Points()
+
+	for _, got := range [][]geo.Point{ls.Points(), lr.Points(), append(cs.Points(), points[3]), mp.Points(), triangle[:]} {
+		if !cmp.Equal(got, points) {
+			t.Errorf("points %v were not equal to %v", got, points)
+		}
+	}
+}
@@ -5,8 +5,6 @@ import (
 	"encoding/binary"
 	"fmt"
 	"strings"
-
-	"github.com/google/go-cmp/cmp"
 )
 
 /* https://postgis.net/docs/using_postgis_dbmanagement.html#LinearRing
@@ -15,7 +13,7 @@ last points must be equal, and the line must not self-intersect.
 */
 
 type LinearRing struct {
-	Points     []Point
+	Coords     []float64
 	Dimensions Dimensions
 }
 
@@ -29,25 +27,58 @@ func (l LinearRing) String() string {
 	sb.WriteString("(LinearRing ")
 	sb.WriteString(l.Dimensions.String())
 	sb.WriteString(" [")
-	for _, p := range l.Points {
-		sb.WriteString(p.String())
-		sb.WriteString(" ")
-	}
+	writeCoordsString(&sb, l.Coords, l.Dimensions)
 
 	sb.WriteString("])")
 	return sb.String()
 }
 
+// Report whether the LinearRing equals another geometry, as ST_OrderingEquals
+// or as set by the EqualOptions
+func (l LinearRing) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&l, other, o.Tolerance)
+	}
+	m, ok := subtypeAs[LinearRing](other)
+	return ok && l.Dimensions == m.Dimensions && sequencesEqual(l.Coords, m.Coords, l.Dimensions, 1, o)
+}
+
 // Get the dimensions of the geometry
 func (l LinearRing) GetDimensions() Dimensions {
 	return l.Dimensions
 }
 
+// Get the bounding box of the LinearRing in each of its dimensions
+func (l LinearRing) Envelope() Envelope {
+	return coordsEnvelope(l.Coords, l.Dimensions)
+}
+
 // Returns the expected byte length for a LinearRing of given dimensions and length
 func LinearRingByteLength(dimensions Dimensions, length uint32) uint32 {
 	return PointByteLength(dimensions) * length
 }
 
+// Get the number of points in the LinearRing
+func (l LinearRing) NumPoints() int {
+	return coordCount(l.Coords, l.Dimensions)
+}
+
+// Get the ordinates of the point at index i. The slice shares storage with the LinearRing.
+func (l LinearRing) Coord(i int) []float64 {
+	return coordAt(l.Coords, l.Dimensions, i)
+}
+
+// Get the point at index i. The point shares storage with the LinearRing.
+func (l LinearRing) PointN(i int) Point {
+	return Point{Coords: l.Coord(i), Dimensions: l.Dimensions}
+}
+
+// Get the points of the LinearRing including the closing point, each sharing storage with it
+func (l LinearRing) Points() []Point {
+	return pointsFromCoords(l.Coords, l.Dimensions)
+}
+
 // Create a LinearRing from a slice of Points of the same dimensions.
 // First and last point must have the same coordinates, and length
 // must be at least 3
@@ -55,39 +86,68 @@ func NewLinearRing(p []Point) (*LinearRing, error) {
 	if len(p) < 3 {
 		return nil, fmt.Errorf("linearring must have length of at least 3")
 	}
-	if !cmp.Equal(p[0], p[len(p)-1]) {
+	coords, err := flattenPoints(p, p[0].GetDimensions())
+	if err != nil {
+		return nil, fmt.Errorf("error creating linearring, %v", err)
+	}
+	return LinearRingFromCoords(coords, p[0].GetDimensions())
+}
+
+// Create a LinearRing from a flat slice of ordinates and Dimensions.
+// First and last point must have the same coordinates, and length
+// must be at least 3 points
+func LinearRingFromCoords(coords []float64, dimensions Dimensions) (*LinearRing, error) {
+	if err := checkCoords(coords, dimensions); err != nil {
+		return nil, fmt.Errorf("error creating linearring, %v", err)
+	}
+	l := LinearRing{Coords: coords, Dimensions: dimensions}
+	if l.NumPoints() < 3 {
+		return nil, fmt.Errorf("linearring must have length of at least 3")
+	}
+	if !coordsEqual(l.Coord(0), l.Coord(l.NumPoints()-1)) {
 		return nil, fmt.Errorf("first and last point of linearring must be equal")
 	}
-	l := LinearRing{}
-	l.Dimensions = p[0].GetDimensions()
-	l.Points = p
 	return &l, nil
 }
 
-// Create a new LinearRing from input byte buffer in EWKB format and dimensions.
-func LinearRingFromEWKB(b *bytes.Buffer, dimensions Dimensions) (*LinearRing, error) {
+// Create a new LinearRing from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func LinearRingFromEWKB(b *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*LinearRing, error) {
+	d, err := newDecodeState("LinearRing", b.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return linearRingFromEWKB(b, dimensions, d)
+}
+
+func linearRingFromEWKB(b *bytes.Buffer, dimensions Dimensions, d *decodeState) (*LinearRing, error) {
 
 	lr := LinearRing{}
 	lr.Dimensions = dimensions
-	if b.Len() < 4 {
-		return nil, fmt.Errorf("byte array for linearring must be at least length 4")
+	count, err := d.readCount(b, "linearring")
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, d.errorAt(d.offset(b)-4, fmt.Errorf("linearring must contain at least one point"))
 	}
-	count := binary.LittleEndian.Uint32(b.Next(4))
 
-	if uint32(b.Len()) < LinearRingByteLength(dimensions, count) {
-		return nil, fmt.Errorf("input for linearring is too short (%v) for requested length %v, dimensions %v", b.Len(), count, dimensions)
+	start := d.offset(b)
+	coords, err := d.readCoords(b, dimensions, count)
+	if err != nil {
+		return nil, err
 	}
+	lr.Coords = coords
 
-	for i := 0; i < int(count); i++ {
-		point, err := PointFromEWKB(b, dimensions)
-		if err != nil {
-			return nil, fmt.Errorf(err.Error())
+	if !coordsEqual(lr.Coord(0), lr.Coord(lr.NumPoints()-1)) {
+		if d.opts.Mode != DecodeLenient {
+			return nil, d.errorAt(start, fmt.Errorf("first and last point of linearring must be equal"))
 		}
-
-		lr.Points = append(lr.Points, *point)
+		d.warn(start, "closed linearring by repeating its first point")
+		lr.Coords = append(lr.Coords, lr.Coord(0)...)
 	}
-	if !cmp.Equal(lr.Points[0], lr.Points[len(lr.Points)-1]) {
-		return nil, fmt.Errorf("first and last point of linearring must be equal")
+	if d.opts.Mode == DecodeStrict && lr.NumPoints() < 4 {
+		return nil, d.errorAt(start, fmt.Errorf("linearring must have at least 4 points, %v provided", lr.NumPoints()))
 	}
 
 	return &lr, nil
@@ -107,13 +167,41 @@ func (l LinearRing) GetEWKB(includeGeoType bool) bytes.Buffer {
 	//}
 
 	// Encode the length
-	lenBytes := binary.LittleEndian.AppendUint32([]byte{}, uint32(len(l.Points)))
+	lenBytes := binary.LittleEndian.AppendUint32([]byte{}, uint32(l.NumPoints()))
 	buf.Write(lenBytes)
 
-	for _, p := range l.Points {
-		// no BOM or geotype
-		lr := p.GetEWKB(false)
-		lr.WriteTo(buf)
-	}
+	// no BOM or geotype
+	writeCoords(buf, l.Coords)
 	return *buf
 }
+
+// Report whether the LinearRing is valid, as ST_IsValid of a polygon of the ring
+func (l LinearRing) IsValid() bool {
+	return l.Validate() == nil
+}
+
+// Check the LinearRing is closed, has at least 4 points, 3 of them distinct,
+// and does not intersect itself
+func (l LinearRing) Validate() error {
+	return validityError(validateRing(l.Coords, l.Dimensions), "LinearRing")
+}
+
+// Get the area of the LinearRing, which has none
+func (l LinearRing) Area() float64 {
+	return 0
+}
+
+// Get the length of the LinearRing, as ST_Length
+func (l LinearRing) Length() float64 {
+	return polylineLength(l.Coords, l.Dimensions)
+}
+
+// Get the perimeter of the LinearRing, which has none
+func (l LinearRing) Perimeter() float64 {
+	return 0
+}
+
+// Get the minimum distance between the LinearRing and another geometry, as ST_Distance
+func (l LinearRing) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&l, other)
+}
@@ -0,0 +1,424 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/*
+	https://postgis.net/docs/ST_MakeValid.html
+
+MakeValid rebuilds polygons from their rings as the "linework" method of
+ST_MakeValid does. Repeated points are removed and rings are closed, then the
+rings are split where they cross or touch. The area of the result is the area
+covered by an odd number of rings, so a bow-tie becomes two triangles, holes
+outside their shell become polygons and overlapping polygons of a
+multipolygon leave a hole where they overlap. The boundary of that area is
+traced into shells and holes, and each hole assigned to the smallest shell
+containing it.
+
+Z and M are kept, and interpolated at points where rings are split. Rings
+enclosing no area are dropped, so a polygon may become empty.
+*/
+
+// Get a valid Polygon or MultiPolygon covering the area of the Polygon, as ST_MakeValid
+func (p Polygon) MakeValid() (GeometrySubtype, error) {
+	polygons, err := makeValid([]Polygon{p}, p.Dimensions)
+	if err != nil {
+		return nil, err
+	}
+	if len(polygons) == 1 {
+		return &polygons[0], nil
+	}
+	if len(polygons) == 0 {
+		return &Polygon{Dimensions: p.Dimensions}, nil
+	}
+	return &MultiPolygon{Polygons: polygons, Dimensions: p.Dimensions}, nil
+}
+
+// Get a valid MultiPolygon covering the area of the MultiPolygon, as ST_MakeValid
+func (mp MultiPolygon) MakeValid() (*MultiPolygon, error) {
+	polygons, err := makeValid(mp.Polygons, mp.Dimensions)
+	if err != nil {
+		return nil, err
+	}
+	return &MultiPolygon{Polygons: polygons, Dimensions: mp.Dimensions}, nil
+}
+
+// Get a valid Polygon or MultiPolygon covering the area of the CurvePolygon
+// linearized with DefaultSegmentsPerQuarter, as ST_MakeValid
+func (c CurvePolygon) MakeValid() (GeometrySubtype, error) {
+	p, err := c.Linearize(DefaultSegmentsPerQuarter)
+	if err != nil {
+		return nil, err
+	}
+	return p.MakeValid()
+}
+
+// Relative distance within which points are the same node
+const snapTolerance = 1e-12
+
+// Times edges are split again after rounding of the points they were split at
+const maxSplitPasses = 8
+
+// A node of the rings, where they are split
+type node struct {
+	coords    []float64 // ordinates of the node
+	neighbors []int     // nodes joined by boundary edges, anticlockwise from the -X axis
+}
+
+// An edge between two nodes, in order, and the segments it is part of
+type edge struct {
+	nodes   [2]int
+	sources []int
+}
+
+// Builds the nodes and edges of the rings of polygons
+type ringGraph struct {
+	dimensions Dimensions
+	nodes      []node
+	index      map[[2]float64]int
+	tolerance  float64 // distance within which points are the same node, beyond rounding
+}
+
+// Rebuild polygons from their rings, as the area covered by an odd number of rings
+func makeValid(polygons []Polygon, dimensions Dimensions) ([]Polygon, error) {
+	g := ringGraph{dimensions: dimensions, index: map[[2]float64]int{}}
+
+	// Collect the segments of the cleaned rings
+	var segments [][2][]float64
+	for i, p := range polygons {
+		for j, ring := range p.LinearRings {
+			if ring.Dimensions != dimensions {
+				return nil, fmt.Errorf("ring %v of polygon %v has dimensions %v, expected %v", j, i, ring.Dimensions, dimensions)
+			}
+			if e := validateCoords(ring.Coords, ring.Dimensions, false); e != nil {
+				return nil, fmt.Errorf("ring %v of polygon %v: %w", j, i, e)
+			}
+			points := cleanRing(ring.Coords, ring.Dimensions)
+			for k := 1; k < len(points); k++ {
+				segments = append(segments, [2][]float64{points[k-1], points[k]})
+			}
+		}
+	}
+
+	// Split segments where others cross or touch them. Edges occurring an odd
+	// number of times bound the area, and are split again until rounding of
+	// the points they were split at leaves none crossing.
+	var edges [][2]int
+	for pass := 0; ; pass++ {
+		all, split := g.split(segments)
+		edges = edges[:0]
+		for _, e := range all {
+			if len(e.sources)%2 == 1 {
+				edges = append(edges, e.nodes)
+			}
+		}
+		if !split || pass == maxSplitPasses {
+			break
+		}
+		segments = segments[:0]
+		for _, e := range edges {
+			segments = append(segments, [2][]float64{g.nodes[e[0]].coords, g.nodes[e[1]].coords})
+		}
+	}
+	for _, e := range edges {
+		g.nodes[e[0]].neighbors = append(g.nodes[e[0]].neighbors, e[1])
+		g.nodes[e[1]].neighbors = append(g.nodes[e[1]].neighbors, e[0])
+	}
+	for i := range g.nodes {
+		g.sortNeighbors(i)
+	}
+
+	var shells, holes [][]int
+	for _, cycle := range g.cycles() {
+		if !g.insideLeft(cycle) {
+			continue
+		}
+		switch area := g.signedArea(cycle); {
+		case area > 0:
+			shells = append(shells, cycle)
+		case area < 0:
+			holes = append(holes, cycle)
+		}
+	}
+
+	// Assign each hole to the smallest shell containing it
+	result := make([]Polygon, len(shells))
+	rings := make([][][2]float64, len(shells))
+	for i, shell := range shells {
+		result[i] = Polygon{LinearRings: []LinearRing{g.ring(shell)}, Dimensions: dimensions}
+		rings[i] = g.xyRing(shell)
+	}
+	for _, hole := range holes {
+		holeRing := g.xyRing(hole)
+		best, bestArea := -1, math.Inf(1)
+		for i, shell := range rings {
+			if locateInRing(testPoint(holeRing, shell), shell) != inside {
+				continue
+			}
+			if area := g.signedArea(shells[i]); area < bestArea {
+				best, bestArea = i, area
+			}
+		}
+		if best >= 0 {
+			result[best].LinearRings = append(result[best].LinearRings, g.ring(hole))
+		}
+	}
+	return result, nil
+}
+
+// Get the points of a ring without consecutive repeated points, closed, or
+// nil if it has fewer than 3 distinct points
+func cleanRing(coords []float64, dimensions Dimensions) [][]float64 {
+	var points [][]float64
+	for i := 0; i < coordCount(coords, dimensions); i++ {
+		p := coordAt(coords, dimensions, i)
+		if len(points) == 0 || xy(points[len(points)-1]) != xy(p) {
+			points = append(points, p)
+		}
+	}
+	if len(points) > 1 && xy(points[0]) == xy(points[len(points)-1]) {
+		points = points[:len(points)-1]
+	}
+	if len(points) < 3 {
+		return nil
+	}
+	return append(points, points[0])
+}
+
+// Get the XY of a point
+func xy(p []float64) [2]float64 {
+	return [2]float64{p[0], p[1]}
+}
+
+// Split segments at the points where others cross or touch them, and get the
+// edges between those points. Report whether any segment was split.
+func (g *ringGraph) split(segments [][2][]float64) ([]edge, bool) {
+	sources := map[[2]int][]int{}
+	split := false
+	for i, s := range segments {
+		points := [][]float64{s[0], s[1]}
+		for j, t := range segments {
+			if i == j {
+				continue
+			}
+			// Find crossings with the segments in order, so both are split at
+			// the same point
+			a, b := s, t
+			if j < i {
+				a, b = t, s
+			}
+			kind, p := intersectSegments(xy(a[0]), xy(a[1]), xy(b[0]), xy(b[1]))
+			switch kind {
+			case crossing:
+				points = append(points, interpolate(s[0], s[1], p))
+			case touching, overlapping:
+				for _, q := range t {
+					if onSegment(xy(q), xy(s[0]), xy(s[1])) {
+						points = append(points, interpolate(s[0], s[1], xy(q)))
+					}
+				}
+			}
+		}
+		sortAlong(points, s[0])
+
+		var nodes []int
+		for _, p := range points {
+			if n := g.node(p); len(nodes) == 0 || nodes[len(nodes)-1] != n {
+				nodes = append(nodes, n)
+			}
+		}
+		split = split || len(nodes) != 2
+		for k := 1; k < len(nodes); k++ {
+			a, b := nodes[k-1], nodes[k]
+			e := [2]int{min(a, b), max(a, b)}
+			sources[e] = append(sources[e], i)
+		}
+	}
+
+	var edges []edge
+	for e, s := range sources {
+		edges = append(edges, edge{nodes: e, sources: s})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i].nodes, edges[j].nodes
+		return a[0] < b[0] || a[0] == b[0] && a[1] < b[1]
+	})
+	return edges, split
+}
+
+// Get the ordinates of the point of segment a-b at XY, interpolating the others
+func interpolate(a []float64, b []float64, p [2]float64) []float64 {
+	if p == xy(a) {
+		return a
+	}
+	if p == xy(b) {
+		return b
+	}
+	t := 0.0
+	if length := math.Hypot(b[0]-a[0], b[1]-a[1]); length > 0 {
+		t = math.Hypot(p[0]-a[0], p[1]-a[1]) / length
+	}
+	q := []float64{p[0], p[1]}
+	for i := 2; i < len(a); i++ {
+		q = append(q, a[i]+t*(b[i]-a[i]))
+	}
+	return q
+}
+
+// Sort points of a segment by distance from its start
+func sortAlong(points [][]float64, start []float64) {
+	sort.SliceStable(points, func(i, j int) bool {
+		return math.Hypot(points[i][0]-start[0], points[i][1]-start[1]) < math.Hypot(points[j][0]-start[0], points[j][1]-start[1])
+	})
+}
+
+// Get the node at the XY of the point, adding one if there is none. Points
+// within rounding error of a node, as where several segments cross, share it.
+func (g *ringGraph) node(p []float64) int {
+	if i, ok := g.index[xy(p)]; ok {
+		return i
+	}
+	tolerance := math.Max(g.tolerance, snapTolerance*math.Max(1, math.Max(math.Abs(p[0]), math.Abs(p[1]))))
+	for i, n := range g.nodes {
+		if math.Abs(n.coords[0]-p[0]) <= tolerance && math.Abs(n.coords[1]-p[1]) <= tolerance {
+			g.index[xy(p)] = i
+			return i
+		}
+	}
+	g.nodes = append(g.nodes, node{coords: p})
+	g.index[xy(p)] = len(g.nodes) - 1
+	return len(g.nodes) - 1
+}
+
+// Get the angle of the direction from node a to node b
+func (g *ringGraph) angle(a int, b int) float64 {
+	p, q := g.nodes[a].coords, g.nodes[b].coords
+	return math.Atan2(q[1]-p[1], q[0]-p[0])
+}
+
+// Sort the neighbors of a node anticlockwise
+func (g *ringGraph) sortNeighbors(i int) {
+	n := g.nodes[i].neighbors
+	sort.Slice(n, func(a, b int) bool { return g.angle(i, n[a]) < g.angle(i, n[b]) })
+}
+
+// Trace the boundary edges into simple cycles, each keeping the area it
+// bounds on its left
+func (g *ringGraph) cycles() [][]int {
+	visited := map[[2]int]bool{}
+	var cycles [][]int
+	for u := range g.nodes {
+		for _, v := range g.nodes[u].neighbors {
+			if visited[[2]int{u, v}] {
+				continue
+			}
+
+			// Follow the sharpest left turn at each node until back at the start
+			path := []int{u}
+			from, to := u, v
+			for !visited[[2]int{from, to}] {
+				visited[[2]int{from, to}] = true
+				path = append(path, to)
+				neighbors := g.nodes[to].neighbors
+				k := 0
+				for neighbors[k] != from {
+					k++
+				}
+				from, to = to, neighbors[(k+len(neighbors)-1)%len(neighbors)]
+			}
+			cycles = append(cycles, splitCycle(path[:len(path)-1])...)
+		}
+	}
+	return cycles
+}
+
+// Split a cycle of nodes that visits a node more than once into simple cycles
+func splitCycle(path []int) [][]int {
+	var cycles [][]int
+	var stack []int
+	position := map[int]int{}
+	for _, n := range path {
+		if i, ok := position[n]; ok {
+			if len(stack)-i > 2 {
+				cycles = append(cycles, append([]int(nil), stack[i:]...))
+			}
+			for _, m := range stack[i+1:] {
+				delete(position, m)
+			}
+			stack = stack[:i+1]
+			continue
+		}
+		position[n] = len(stack)
+		stack = append(stack, n)
+	}
+	if len(stack) > 2 {
+		cycles = append(cycles, stack)
+	}
+	return cycles
+}
+
+// Report whether the area to the left of a cycle is covered by an odd number
+// of rings, by counting the boundary edges crossed by a ray to the left from
+// the middle of its first edge
+func (g *ringGraph) insideLeft(cycle []int) bool {
+	a, b := g.nodes[cycle[0]].coords, g.nodes[cycle[1]].coords
+	first := [2]int{min(cycle[0], cycle[1]), max(cycle[0], cycle[1])}
+	crossings := 0
+	for i := range g.nodes {
+		for _, j := range g.nodes[i].neighbors {
+			if j > i && first != [2]int{i, j} && leftRayCrosses(a, b, g.nodes[i].coords, g.nodes[j].coords) {
+				crossings++
+			}
+		}
+	}
+	return crossings%2 == 1
+}
+
+// Report whether the ray to the left from the middle of segment a-b crosses
+// segment p-q. Ends on the line of the ray count as below it, so a ray through
+// a point where segments meet crosses only one of them.
+func leftRayCrosses(a []float64, b []float64, p []float64, q []float64) bool {
+	m := [2]float64{(a[0] + b[0]) / 2, (a[1] + b[1]) / 2}
+	n := [2]float64{a[1] - b[1], b[0] - a[0]}
+
+	// Distance of p and q from the line of the ray, and along it
+	sp := n[0]*(p[1]-m[1]) - n[1]*(p[0]-m[0])
+	sq := n[0]*(q[1]-m[1]) - n[1]*(q[0]-m[0])
+	if (sp > 0) == (sq > 0) {
+		return false
+	}
+	ap := n[0]*(p[0]-m[0]) + n[1]*(p[1]-m[1])
+	aq := n[0]*(q[0]-m[0]) + n[1]*(q[1]-m[1])
+	return ap+sp/(sp-sq)*(aq-ap) > 0
+}
+
+// Get twice the signed area of a cycle, positive if anticlockwise
+func (g *ringGraph) signedArea(cycle []int) float64 {
+	area := 0.0
+	for i, n := range cycle {
+		p, q := g.nodes[n].coords, g.nodes[cycle[(i+1)%len(cycle)]].coords
+		area += p[0]*q[1] - q[0]*p[1]
+	}
+	return area
+}
+
+// Get a cycle as a closed LinearRing
+func (g *ringGraph) ring(cycle []int) LinearRing {
+	var coords []float64
+	for _, n := range append(cycle, cycle[0]) {
+		coords = append(coords, g.nodes[n].coords...)
+	}
+	return LinearRing{Coords: coords, Dimensions: g.dimensions}
+}
+
+// Get the XY coordinates of a cycle as a closed ring
+func (g *ringGraph) xyRing(cycle []int) [][2]float64 {
+	var ring [][2]float64
+	for _, n := range append(cycle, cycle[0]) {
+		ring = append(ring, xy(g.nodes[n].coords))
+	}
+	return ring
+}
@@ -0,0 +1,150 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestMakeValid(t *testing.T) {
+	multiPolygon := func(polygons ...*geo.Polygon) *geo.MultiPolygon {
+		mp := &geo.MultiPolygon{Dimensions: geo.XY}
+		for _, p := range polygons {
+			mp.Polygons = append(mp.Polygons, *p)
+		}
+		return mp
+	}
+
+	tests := []struct {
+		name     string
+		geometry geo.GeometrySubtype
+		polygons int // polygons in the result
+		rings    int // rings in the result
+		area     float64
+	}{
+		{"valid", xyPolygon(xySquare(0, 0, 10), xySquare(2, 2, 2)), 1, 2, 96},
+		{"bow-tie", xyPolygon(xyRing(0, 0, 2, 2, 2, 0, 0, 2, 0, 0)), 2, 2, 2},
+		{"unclosed ring with repeated points", xyPolygon(xyRing(0, 0, 4, 0, 4, 0, 4, 4, 0, 4)), 1, 1, 16},
+		{"collinear ring", xyPolygon(xyRing(0, 0, 1, 1, 2, 2, 0, 0)), 0, 0, 0},
+		{"hole outside shell", xyPolygon(xySquare(0, 0, 10), xySquare(20, 20, 2)), 2, 2, 104},
+		{"hole crossing shell", xyPolygon(xySquare(0, 0, 10), xySquare(8, 2, 4)), 2, 2, 100 - 8 + 8},
+		{"figure of eight", xyPolygon(xyRing(0, 0, 2, 0, 2, 2, 4, 2, 4, 4, 2, 4, 2, 2, 0, 2, 0, 0)), 2, 2, 8},
+		{"nested shells", multiPolygon(xyPolygon(xySquare(0, 0, 10)), xyPolygon(xySquare(2, 2, 2))), 1, 2, 96},
+		{"overlapping shells", multiPolygon(xyPolygon(xySquare(0, 0, 2)), xyPolygon(xySquare(1, 0, 2))), 2, 2, 4},
+		{"shell in hole", multiPolygon(xyPolygon(xySquare(0, 0, 10), xySquare(1, 1, 8)), xyPolygon(xySquare(2, 2, 2))), 2, 3, 40},
+		{"shared edge", multiPolygon(xyPolygon(xySquare(0, 0, 1)), xyPolygon(xySquare(1, 0, 1))), 1, 1, 2},
+		{"hole equal to shell", xyPolygon(xySquare(0, 0, 4), xySquare(0, 0, 4)), 0, 0, 0},
+		{"ring repeated three times", xyPolygon(xySquare(0, 0, 4), xySquare(0, 0, 4), xySquare(0, 0, 4)), 1, 1, 16},
+		{"far from the origin", xyPolygon(xyRing(1e6, 1e6, 1e6+2, 1e6+2, 1e6+2, 1e6, 1e6, 1e6+2, 1e6, 1e6)), 2, 2, 2},
+		{"hole touching shell at a vertex", xyPolygon(xySquare(0, 0, 10), xyRing(0, 5, 2, 3, 2, 7, 0, 5)), 1, 2, 96},
+		{"curvepolygon", &geo.CurvePolygon{Geometry: []geo.GeometrySubtype{
+			&geo.CircularString{Coords: []float64{0, 0, 2, 0, 0, 0}, Dimensions: geo.XY}}, Dimensions: geo.XY}, 1, 1, math.Pi},
+	}
+	for _, test := range tests {
+		var result geo.GeometrySubtype
+		var err error
+		switch g := test.geometry.(type) {
+		case *geo.Polygon:
+			result, err = g.MakeValid()
+		case *geo.MultiPolygon:
+			result, err = g.MakeValid()
+		case *geo.CurvePolygon:
+			result, err = g.MakeValid()
+		}
+		if err != nil {
+			t.Errorf("%v: %v", test.name, err)
+			continue
+		}
+		if err := result.Validate(); err != nil {
+			t.Errorf("%v: result %v is not valid: %v", test.name, result, err)
+		}
+
+		var polygons []geo.Polygon
+		switch r := result.(type) {
+		case *geo.Polygon:
+			if len(r.LinearRings) > 0 {
+				polygons = []geo.Polygon{*r}
+			}
+		case *geo.MultiPolygon:
+			polygons = r.Polygons
+		}
+		rings, area := 0, 0.0
+		for _, p := range polygons {
+			rings += len(p.LinearRings)
+			for i, r := range p.LinearRings {
+				if i == 0 {
+					area += ringArea(r)
+				} else {
+					area -= ringArea(r)
+				}
+			}
+		}
+		if len(polygons) != test.polygons || rings != test.rings {
+			t.Errorf("%v: expected %v polygons of %v rings, got %v", test.name, test.polygons, test.rings, result)
+		}
+		if math.Abs(area-test.area) > 1e-2 {
+			t.Errorf("%v: expected area %v, got %v", test.name, test.area, area)
+		}
+	}
+}
+
+func TestMakeValidInterpolatesZ(t *testing.T) {
+	p := geo.Polygon{LinearRings: []geo.LinearRing{{
+		Coords:     []float64{0, 0, 0, 2, 2, 2, 2, 0, 0, 0, 2, 2, 0, 0, 0},
+		Dimensions: geo.XYZ,
+	}}, Dimensions: geo.XYZ}
+	result, err := p.MakeValid()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mp, ok := result.(*geo.MultiPolygon)
+	if !ok {
+		t.Fatalf("expected multipolygon, got %v", result)
+	}
+	for _, polygon := range mp.Polygons {
+		ring := polygon.LinearRings[0]
+		for i := 0; i < ring.NumPoints(); i++ {
+			if c := ring.Coord(i); c[0] == 1 && c[1] == 1 && c[2] != 1 {
+				t.Errorf("expected Z of 1 at the crossing, got %v", c[2])
+			}
+		}
+	}
+}
+
+func TestMakeValidSnapsNearbyPoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		coords []float64
+		points int // points in the resulting ring
+	}{
+		{"sliver", []float64{0, 0, 4, 0, 4, 4, 2, 4, 2 + 1e-13, 4 + 1e-13, 0, 4, 0, 0}, 6},
+		{"spike", []float64{0, 0, 4, 0, 4, 2, 4 - 1e-13, 2 + 1e-13, 4, 2 + 2e-13, 4, 4, 0, 4, 0, 0}, 6},
+		{"apart", []float64{0, 0, 4, 0, 4, 4, 0, 4, 2, 4 - 1e-6, 0, 0}, 6},
+	}
+	for _, test := range tests {
+		result, err := xyPolygon(xyRing(test.coords...)).MakeValid()
+		if err != nil {
+			t.Errorf("%v: %v", test.name, err)
+			continue
+		}
+		polygon, ok := result.(*geo.Polygon)
+		if !ok || len(polygon.LinearRings) != 1 {
+			t.Errorf("%v: expected a polygon of 1 ring, got %v", test.name, result)
+			continue
+		}
+		if n := polygon.LinearRings[0].NumPoints(); n != test.points {
+			t.Errorf("%v: expected %v points, got %v", test.name, test.points, result)
+		}
+	}
+}
+
+// Get the absolute area of a ring
+func ringArea(r geo.LinearRing) float64 {
+	area := 0.0
+	for i := 1; i < r.NumPoints(); i++ {
+		p, q := r.Coord(i-1), r.Coord(i)
+		area += p[0]*q[1] - q[0]*p[1]
+	}
+	return math.Abs(area) / 2
+}
@@ -0,0 +1,271 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+	https://postgis.net/docs/ST_Area.html
+	https://postgis.net/docs/ST_Length.html
+	https://postgis.net/docs/ST_Perimeter.html
+	https://postgis.net/docs/ST_Distance.html
+
+Measurements are planar, in the units of the coordinates, as PostGIS gives for
+geometry. Area and Perimeter are those of polygons and surfaces, and Length
+that of lines and curves, so each is 0 for geometries of other kinds and
+collections sum those of their members. Arcs are measured exactly, as circular
+arcs rather than their linearization.
+
+Distance is the minimum distance in the XY plane between any points of two
+geometries, 0 if they intersect, with arcs linearized with
+DefaultSegmentsPerQuarter.
+
+Area3D and Perimeter3D of polygons, triangles and the surfaces made of them use
+Z, as ST_3DArea and ST_3DPerimeter, with the area of each ring that of the
+plane it lies in.
+*/
+
+// Get twice the signed area of the polygon between a point sequence and the
+// origin, positive if anticlockwise. The sum over the parts of a closed ring
+// is twice the signed area of the ring.
+func shoelace(coords []float64, dimensions Dimensions) float64 {
+	sum := 0.0
+	for i := 1; i < coordCount(coords, dimensions); i++ {
+		p, q := coordAt(coords, dimensions, i-1), coordAt(coords, dimensions, i)
+		sum += p[0]*q[1] - q[0]*p[1]
+	}
+	return sum
+}
+
+// Get the length of a point sequence in the XY plane
+func polylineLength(coords []float64, dimensions Dimensions) float64 {
+	length := 0.0
+	for i := 1; i < coordCount(coords, dimensions); i++ {
+		p, q := coordAt(coords, dimensions, i-1), coordAt(coords, dimensions, i)
+		length += math.Hypot(q[0]-p[0], q[1]-p[1])
+	}
+	return length
+}
+
+// Get the length of a point sequence, using Z if it has Z
+func polylineLength3D(coords []float64, dimensions Dimensions) float64 {
+	if !dimensions.HasZ() {
+		return polylineLength(coords, dimensions)
+	}
+	length := 0.0
+	for i := 1; i < coordCount(coords, dimensions); i++ {
+		p, q := coordAt(coords, dimensions, i-1), coordAt(coords, dimensions, i)
+		length += math.Sqrt((q[0]-p[0])*(q[0]-p[0]) + (q[1]-p[1])*(q[1]-p[1]) + (q[2]-p[2])*(q[2]-p[2]))
+	}
+	return length
+}
+
+// Get the area of a closed point sequence in the plane it lies in, using Z if
+// it has Z, as the length of its vector area
+func ringArea3D(coords []float64, dimensions Dimensions) float64 {
+	if !dimensions.HasZ() {
+		return math.Abs(shoelace(coords, dimensions)) / 2
+	}
+	var area [3]float64
+	for i := 1; i < coordCount(coords, dimensions); i++ {
+		p, q := coordAt(coords, dimensions, i-1), coordAt(coords, dimensions, i)
+		area[0] += p[1]*q[2] - p[2]*q[1]
+		area[1] += p[2]*q[0] - p[0]*q[2]
+		area[2] += p[0]*q[1] - p[1]*q[0]
+	}
+	return math.Sqrt(area[0]*area[0]+area[1]*area[1]+area[2]*area[2]) / 2
+}
+
+// Get the area of the rings of a polygon, with the area of holes removed
+// from that of the shell
+func ringsArea(rings []LinearRing, area func(coords []float64, dimensions Dimensions) float64) float64 {
+	total := 0.0
+	for i, ring := range rings {
+		if i == 0 {
+			total += area(ring.Coords, ring.Dimensions)
+		} else {
+			total -= area(ring.Coords, ring.Dimensions)
+		}
+	}
+	return total
+}
+
+// Get the area of a closed point sequence in the XY plane
+func ringArea(coords []float64, dimensions Dimensions) float64 {
+	return math.Abs(shoelace(coords, dimensions)) / 2
+}
+
+// Call f with the points of each arc of a CircularString. Points after the
+// last complete arc are ignored.
+func forEachArc(coords []float64, dimensions Dimensions, f func(p0 []float64, p1 []float64, p2 []float64)) {
+	for i := 2; i < coordCount(coords, dimensions); i += 2 {
+		f(coordAt(coords, dimensions, i-2), coordAt(coords, dimensions, i-1), coordAt(coords, dimensions, i))
+	}
+}
+
+// Get the length of the arcs of a CircularString in the XY plane
+func arcsLength(coords []float64, dimensions Dimensions) float64 {
+	length := 0.0
+	forEachArc(coords, dimensions, func(p0, p1, p2 []float64) {
+		if a, ok := arcThrough(p0, p1, p2); ok {
+			length += a.radius * math.Abs(a.sweep)
+		} else {
+			length += math.Hypot(p1[0]-p0[0], p1[1]-p0[1]) + math.Hypot(p2[0]-p1[0], p2[1]-p1[1])
+		}
+	})
+	return length
+}
+
+// Get twice the signed area between the arcs of a CircularString and the
+// origin, as shoelace does for point sequences, with the area between each
+// arc and its chord
+func arcsShoelace(coords []float64, dimensions Dimensions) float64 {
+	sum := 0.0
+	forEachArc(coords, dimensions, func(p0, p1, p2 []float64) {
+		a, ok := arcThrough(p0, p1, p2)
+		if !ok {
+			sum += p0[0]*p1[1] - p1[0]*p0[1] + p1[0]*p2[1] - p2[0]*p1[1]
+			return
+		}
+		theta := math.Abs(a.sweep)
+		segment := a.radius * a.radius * (theta - math.Sin(theta))
+		if a.sweep < 0 {
+			segment = -segment
+		}
+		sum += p0[0]*p2[1] - p2[0]*p0[1] + segment
+	})
+	return sum
+}
+
+// Get twice the signed area between a curve and the origin, as shoelace does
+// for point sequences
+func curveShoelace(g GeometrySubtype) float64 {
+	switch t := g.(type) {
+	case *LineString:
+		return shoelace(t.Coords, t.Dimensions)
+	case *CircularString:
+		return arcsShoelace(t.Coords, t.Dimensions)
+	case *CompoundCurve:
+		sum := 0.0
+		for _, component := range t.Geometry {
+			sum += curveShoelace(component)
+		}
+		return sum
+	}
+	return 0
+}
+
+// Get the minimum distance in the XY plane between two geometries, 0 if they
+// intersect
+func distance(a GeometrySubtype, b GeometrySubtype) (float64, error) {
+	var sets [2]pointSet
+	for i, g := range []GeometrySubtype{a, b} {
+		if g == nil {
+			return 0, fmt.Errorf("distance to a nil geometry is undefined")
+		}
+		if err := sets[i].add(g); err != nil {
+			return 0, err
+		}
+		if len(sets[i].points) == 0 && len(sets[i].lines) == 0 && len(sets[i].polygons) == 0 {
+			return 0, fmt.Errorf("distance to an empty %v is undefined", g.GetGISGeometryType())
+		}
+	}
+
+	// Either may lie within a polygon of the other
+	for i, s := range sets {
+		for _, p := range s.vertices() {
+			for _, polygon := range sets[1-i].polygons {
+				if locateInPolygon(p, polygon) != outside {
+					return 0, nil
+				}
+			}
+		}
+	}
+
+	// Otherwise the nearest points are on their boundaries
+	d := math.Inf(1)
+	segmentsA, segmentsB := sets[0].segments(), sets[1].segments()
+	for _, s := range segmentsA {
+		for _, t := range segmentsB {
+			if kind, _ := intersectSegments(s[0], s[1], t[0], t[1]); kind != disjoint {
+				return 0, nil
+			}
+			d = math.Min(d, math.Min(
+				math.Min(distanceToSegment(s[0], t[0], t[1]), distanceToSegment(s[1], t[0], t[1])),
+				math.Min(distanceToSegment(t[0], s[0], s[1]), distanceToSegment(t[1], s[0], s[1]))))
+		}
+		for _, p := range sets[1].points {
+			d = math.Min(d, distanceToSegment(p, s[0], s[1]))
+		}
+	}
+	for _, p := range sets[0].points {
+		for _, t := range segmentsB {
+			d = math.Min(d, distanceToSegment(p, t[0], t[1]))
+		}
+		for _, q := range sets[1].points {
+			d = math.Min(d, math.Hypot(q[0]-p[0], q[1]-p[1]))
+		}
+	}
+	return d, nil
+}
+
+// Get the segments of the lines and polygon rings of the set
+func (s *pointSet) segments() [][2][2]float64 {
+	segments := append([][2][2]float64(nil), s.lines...)
+	for _, polygon := range s.polygons {
+		for _, ring := range polygon {
+			for i := 1; i < len(ring); i++ {
+				segments = append(segments, [2][2]float64{ring[i-1], ring[i]})
+			}
+		}
+	}
+	return segments
+}
+
+// Get every point and vertex of the set
+func (s *pointSet) vertices() [][2]float64 {
+	vertices := append([][2]float64(nil), s.points...)
+	for _, line := range s.lines {
+		vertices = append(vertices, line[0], line[1])
+	}
+	for _, polygon := range s.polygons {
+		for _, ring := range polygon {
+			vertices = append(vertices, ring...)
+		}
+	}
+	return vertices
+}
+
+// Get the area of the geometry, as ST_Area
+func (g GISGeometry) Area() float64 {
+	if g.Geometry == nil {
+		return 0
+	}
+	return g.Geometry.Area()
+}
+
+// Get the length of the geometry, as ST_Length
+func (g GISGeometry) Length() float64 {
+	if g.Geometry == nil {
+		return 0
+	}
+	return g.Geometry.Length()
+}
+
+// Get the perimeter of the geometry, as ST_Perimeter
+func (g GISGeometry) Perimeter() float64 {
+	if g.Geometry == nil {
+		return 0
+	}
+	return g.Geometry.Perimeter()
+}
+
+// Get the minimum distance between the geometry and another of the same SRID,
+// as ST_Distance
+func (g GISGeometry) Distance(other GISGeometry) (float64, error) {
+	if g.srid() != other.srid() {
+		return 0, fmt.Errorf("distance between geometries of SRID %v and %v", g.srid(), other.srid())
+	}
+	return distance(g.Geometry, other.Geometry)
+}
@@ -0,0 +1,137 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestMeasure(t *testing.T) {
+	curvePolygon := func(rings ...geo.GeometrySubtype) *geo.CurvePolygon {
+		return &geo.CurvePolygon{Geometry: rings, Dimensions: geo.XY}
+	}
+	compoundCurve := func(components ...geo.GeometrySubtype) *geo.CompoundCurve {
+		return &geo.CompoundCurve{Geometry: components, Dimensions: geo.XY}
+	}
+	polygon := xyPolygon(xySquare(0, 0, 10), xySquare(2, 2, 2))
+
+	tests := []struct {
+		name      string
+		geometry  geo.GeometrySubtype
+		area      float64
+		length    float64
+		perimeter float64
+	}{
+		{"point", &geo.Point{Coords: []float64{1, 2}, Dimensions: geo.XY}, 0, 0, 0},
+		{"linestring", xyLineString(0, 0, 3, 4, 3, 5), 0, 6, 0},
+		{"half circle", xyCircularString(0, 0, 1, 1, 2, 0), 0, math.Pi, 0},
+		{"full circle", xyCircularString(0, 0, 2, 0, 0, 0), 0, 2 * math.Pi, 0},
+		{"collinear arc", xyCircularString(0, 0, 1, 0, 2, 0), 0, 2, 0},
+		{"compoundcurve", compoundCurve(xyCircularString(0, 0, 1, 1, 2, 0), xyLineString(2, 0, 4, 0)), 0, math.Pi + 2, 0},
+		{"polygon with hole", polygon, 96, 0, 48},
+		{"triangle", &geo.Triangle{Coords: []float64{0, 0, 4, 0, 0, 3, 0, 0}, Dimensions: geo.XY}, 6, 0, 12},
+		{"multipolygon", &geo.MultiPolygon{Polygons: []geo.Polygon{*polygon, {LinearRings: []geo.LinearRing{xySquare(20, 20, 1)}, Dimensions: geo.XY}}, Dimensions: geo.XY}, 97, 0, 52},
+		{"circle", curvePolygon(xyCircularString(0, 0, 2, 0, 0, 0)), math.Pi, 0, 2 * math.Pi},
+		{"half disk", curvePolygon(compoundCurve(xyCircularString(0, 0, 1, 1, 2, 0), xyLineString(2, 0, 0, 0))), math.Pi / 2, 0, math.Pi + 2},
+		{"square less half disk", curvePolygon(compoundCurve(xyLineString(0, 0, 0, 2, 2, 2, 2, 0), xyCircularString(2, 0, 1, 1, 0, 0))), 4 - math.Pi/2, 0, 6 + math.Pi},
+		{"curvepolygon with hole", curvePolygon(xyLineString(-2, -2, 4, -2, 4, 2, -2, 2, -2, -2), xyCircularString(0, 0, 2, 0, 0, 0)), 24 - math.Pi, 0, 20 + 2*math.Pi},
+		{"multisurface", &geo.MultiSurface{Geometry: []geo.GeometrySubtype{polygon, curvePolygon(xyCircularString(0, 0, 2, 0, 0, 0))}, Dimensions: geo.XY}, 96 + math.Pi, 0, 48 + 2*math.Pi},
+		{"collection", &geo.GeometryCollection{Geometry: []geo.GeometrySubtype{polygon, xyLineString(0, 0, 3, 4)}, Dimensions: geo.XY}, 96, 5, 48},
+	}
+	for _, test := range tests {
+		if a := test.geometry.Area(); math.Abs(a-test.area) > 1e-9 {
+			t.Errorf("%v: expected area %v, got %v", test.name, test.area, a)
+		}
+		if l := test.geometry.Length(); math.Abs(l-test.length) > 1e-9 {
+			t.Errorf("%v: expected length %v, got %v", test.name, test.length, l)
+		}
+		if p := test.geometry.Perimeter(); math.Abs(p-test.perimeter) > 1e-9 {
+			t.Errorf("%v: expected perimeter %v, got %v", test.name, test.perimeter, p)
+		}
+	}
+}
+
+func TestMeasure3D(t *testing.T) {
+	// A unit cube without its top, of 5 faces
+	face := func(coords ...float64) geo.Polygon {
+		return geo.Polygon{LinearRings: []geo.LinearRing{{Coords: coords, Dimensions: geo.XYZ}}, Dimensions: geo.XYZ}
+	}
+	surface := geo.PolyHedralSurface{Polygons: []geo.Polygon{
+		face(0, 0, 0, 0, 1, 0, 1, 1, 0, 1, 0, 0, 0, 0, 0),
+		face(0, 0, 0, 0, 0, 1, 0, 1, 1, 0, 1, 0, 0, 0, 0),
+		face(0, 0, 0, 1, 0, 0, 1, 0, 1, 0, 0, 1, 0, 0, 0),
+		face(1, 1, 0, 1, 1, 1, 1, 0, 1, 1, 0, 0, 1, 1, 0),
+		face(0, 1, 0, 0, 1, 1, 1, 1, 1, 1, 1, 0, 0, 1, 0),
+	}, Dimensions: geo.XYZ}
+	if a := surface.Area(); a != 1 {
+		t.Errorf("expected planar area 1, got %v", a)
+	}
+	if a := surface.Area3D(); math.Abs(a-5) > 1e-12 {
+		t.Errorf("expected area 5, got %v", a)
+	}
+	if p := surface.Perimeter3D(); math.Abs(p-20) > 1e-12 {
+		t.Errorf("expected perimeter 20, got %v", p)
+	}
+
+	tin := geo.TIN{Triangles: []geo.Triangle{
+		{Coords: []float64{0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0}, Dimensions: geo.XYZ},
+		{Coords: []float64{0, 0, 0, 0, 1, 0, 1, 0, 0, 0, 0, 0}, Dimensions: geo.XYZ},
+	}, Dimensions: geo.XYZ}
+	if a := tin.Area(); a != 0.5 {
+		t.Errorf("expected planar area 0.5, got %v", a)
+	}
+	if a := tin.Area3D(); math.Abs(a-1) > 1e-12 {
+		t.Errorf("expected area 1, got %v", a)
+	}
+	if p := tin.Perimeter3D(); math.Abs(p-(4+2*math.Sqrt2)) > 1e-12 {
+		t.Errorf("expected perimeter %v, got %v", 4+2*math.Sqrt2, p)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	donut := xyPolygon(xySquare(0, 0, 10), xySquare(2, 2, 6))
+
+	tests := []struct {
+		name     string
+		a, b     geo.GeometrySubtype
+		distance float64
+	}{
+		{"points", xyPoint(0, 0), xyPoint(3, 4), 5},
+		{"point and line", xyPoint(1, 1), xyLineString(0, 0, 2, 0), 1},
+		{"point beyond line end", xyPoint(5, 4), xyLineString(0, 0, 2, 0), 5},
+		{"crossing lines", xyLineString(0, 0, 2, 2), xyLineString(0, 2, 2, 0), 0},
+		{"parallel lines", xyLineString(0, 0, 2, 0), xyLineString(0, 3, 2, 3), 3},
+		{"point in polygon", xyPoint(1, 1), donut, 0},
+		{"point in hole", xyPoint(5, 4), donut, 2},
+		{"line inside polygon", xyLineString(0.5, 0.5, 1.5, 1.5), donut, 0},
+		{"polygon in hole", &geo.Polygon{LinearRings: []geo.LinearRing{xySquare(4, 4, 1)}, Dimensions: geo.XY}, donut, 2},
+		{"polygons", donut, &geo.Polygon{LinearRings: []geo.LinearRing{xySquare(13, 0, 1)}, Dimensions: geo.XY}, 3},
+		{"circle", &geo.CircularString{Coords: []float64{0, 0, 2, 0, 0, 0}, Dimensions: geo.XY}, xyPoint(1, 4), 3},
+		{"collection", &geo.GeometryCollection{Geometry: []geo.GeometrySubtype{xyPoint(20, 20), xyLineString(11, 0, 11, 5)}, Dimensions: geo.XY}, donut, 1},
+	}
+	for _, test := range tests {
+		for _, pair := range [][2]geo.GeometrySubtype{{test.a, test.b}, {test.b, test.a}} {
+			d, err := pair[0].Distance(pair[1])
+			if err != nil {
+				t.Errorf("%v: %v", test.name, err)
+			} else if math.Abs(d-test.distance) > 1e-3 {
+				t.Errorf("%v: expected distance %v, got %v", test.name, test.distance, d)
+			}
+		}
+	}
+
+	if _, err := xyPoint(0, 0).Distance(&geo.Point{Coords: []float64{math.NaN(), math.NaN()}, Dimensions: geo.XY}); err == nil {
+		t.Error("expected error measuring distance to an empty point")
+	}
+
+	a, b := geo.NewGISGeometry(xyPoint(0, 0)), geo.NewGISGeometry(xyPoint(3, 4))
+	a.SetSRID(4326)
+	if _, err := a.Distance(b); err == nil {
+		t.Error("expected error measuring distance between geometries of different SRIDs")
+	}
+	b.SetSRID(4326)
+	if d, err := a.Distance(b); err != nil || d != 5 {
+		t.Errorf("expected distance 5, got %v, %v", d, err)
+	}
+}
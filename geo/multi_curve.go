@@ -37,11 +37,28 @@ func (mc MultiCurve) String() string {
 	return sb.String()
 }
 
+// Report whether the MultiCurve equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (mc MultiCurve) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&mc, other, o.Tolerance)
+	}
+	md, ok := subtypeAs[MultiCurve](other)
+	return ok && mc.Dimensions == md.Dimensions && membersEqual(mc.Geometry, md.Geometry, o)
+}
+
 // Get the dimensions of the geometry
 func (mc MultiCurve) GetDimensions() Dimensions {
 	return mc.Dimensions
 }
 
+// Get the bounding box of the MultiCurve in each of its dimensions, bounding its
+// arcs rather than their control points
+func (mc MultiCurve) Envelope() Envelope {
+	return membersEnvelope(mc.Geometry, mc.Dimensions)
+}
+
 // Create a MultiCurve from a slice of Geometry of the same dimensions.
 // [Containing only Polygons and CurvePolygons]
 // Length of geometry must be at least 1.
@@ -81,46 +98,61 @@ func (mc *MultiCurve) AddCompoundCurve(cc *CompoundCurve) error {
 	return nil
 }
 
-// Create a new MultiCurve from input byte buffer in EWKB format and dimensions.
-func MultiCurveFromEWKB(b *bytes.Buffer, dimensions Dimensions) (*MultiCurve, error) {
+// Create a new MultiCurve from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func MultiCurveFromEWKB(b *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*MultiCurve, error) {
+	d, err := newDecodeState(pathName(MultiCurveType), b.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return multiCurveFromEWKB(b, dimensions, d)
+}
+
+func multiCurveFromEWKB(b *bytes.Buffer, dimensions Dimensions, d *decodeState) (*MultiCurve, error) {
 
 	multiCurve := MultiCurve{}
 	multiCurve.Dimensions = dimensions
-	if b.Len() < 4 {
-		return nil, fmt.Errorf("byte array for multicurve must be at least length 4")
+	count, err := d.readCount(b, "multicurve")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.enter(b); err != nil {
+		return nil, err
 	}
-	count := binary.LittleEndian.Uint32(b.Next(4))
+	defer d.leave()
 
 	for i := 0; i < int(count); i++ {
-
-		//bom := ByteOrder(data[0]) 	// First byte is Byte Order Mark
-		b.Next(1)
-		geoType := GISGeometryType(binary.LittleEndian.Uint16(b.Next(4))) // 4 bytes for geometry type
-		//flags := data[3] // flags are stored on the 3rd byte
+		d.at(i)
+		geoType, memberDimensions, err := d.readMemberType(b, "multicurve", dimensions, LineStringType, CircularStringType, CompoundCurveType)
+		if err != nil {
+			return nil, err
+		}
+		d.push(pathName(geoType))
 
 		var geometry GeometrySubtype
-		var err error
 
 		switch geoType {
 
 		case LineStringType:
-			geometry, err = LineStringFromEWKB(b, dimensions)
+			geometry, err = lineStringFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case CircularStringType:
-			geometry, err = CircularStringFromEWKB(b, dimensions)
+			geometry, err = circularStringFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 
 		case CompoundCurveType:
-			geometry, err = CompoundCurveFromEWKB(b, dimensions)
+			geometry, err = compoundCurveFromEWKB(b, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 
 		}
+		forceDimensions(geometry, dimensions)
+		d.pop()
 		multiCurve.Geometry = append(multiCurve.Geometry, geometry)
 
 	}
@@ -164,3 +196,42 @@ func (mc MultiCurve) GetEWKB(includeGeoType bool) bytes.Buffer {
 	}
 	return *buf
 }
+
+// Report whether the MultiCurve is valid, as ST_IsValid
+func (mc MultiCurve) IsValid() bool {
+	return mc.Validate() == nil
+}
+
+// Check the members of the MultiCurve are valid LineStrings, CircularStrings
+// and CompoundCurves
+func (mc MultiCurve) Validate() error {
+	e := validateMemberTypes(mc.Geometry, "MultiCurve", LineStringType, CircularStringType, CompoundCurveType)
+	if e == nil {
+		e = validateMembers(mc.Geometry, mc.Dimensions)
+	}
+	return validityError(e, pathName(MultiCurveType))
+}
+
+// Get the area of the MultiCurve, which has none
+func (mc MultiCurve) Area() float64 {
+	return 0
+}
+
+// Get the length of the MultiCurve, as ST_Length
+func (mc MultiCurve) Length() float64 {
+	total := 0.0
+	for _, curve := range mc.Geometry {
+		total += curve.Length()
+	}
+	return total
+}
+
+// Get the perimeter of the MultiCurve, which has none
+func (mc MultiCurve) Perimeter() float64 {
+	return 0
+}
+
+// Get the minimum distance between the MultiCurve and another geometry, as ST_Distance
+func (mc MultiCurve) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&mc, other)
+}
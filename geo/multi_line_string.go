@@ -37,11 +37,39 @@ func (mls MultiLineString) String() string {
 	return sb.String()
 }
 
+// Report whether the MultiLineString equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (mls MultiLineString) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&mls, other, o.Tolerance)
+	}
+	mlt, ok := subtypeAs[MultiLineString](other)
+	if !ok || mls.Dimensions != mlt.Dimensions || len(mls.LineStrings) != len(mlt.LineStrings) {
+		return false
+	}
+	for i, ls := range mls.LineStrings {
+		if !ls.Equals(mlt.LineStrings[i], o) {
+			return false
+		}
+	}
+	return true
+}
+
 // Get the dimensions of the geometry
 func (mls MultiLineString) GetDimensions() Dimensions {
 	return mls.Dimensions
 }
 
+// Get the bounding box of the MultiLineString in each of its dimensions
+func (mls MultiLineString) Envelope() Envelope {
+	e := NewEnvelope(mls.Dimensions)
+	for _, ls := range mls.LineStrings {
+		e.add(ls.Envelope())
+	}
+	return e.clear()
+}
+
 // Create a MultiLineString from a slice of LineStrings of the same dimensions.
 // Length must be at least 1
 func NewMultiLineString(l []LineString) (*MultiLineString, error) {
@@ -55,28 +83,42 @@ func NewMultiLineString(l []LineString) (*MultiLineString, error) {
 	return &m, nil
 }
 
-// Create a new MultiLineString from input byte buffer in EWKB format and dimensions.
-func MultiLineStringFromEWKB(buffer *bytes.Buffer, dimensions Dimensions) (*MultiLineString, error) {
+// Create a new MultiLineString from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func MultiLineStringFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*MultiLineString, error) {
+	d, err := newDecodeState(pathName(MultiLineStringType), buffer.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return multiLineStringFromEWKB(buffer, dimensions, d)
+}
+
+func multiLineStringFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, d *decodeState) (*MultiLineString, error) {
 
 	mls := MultiLineString{}
 	mls.Dimensions = dimensions
-	if buffer.Len() < 4 {
-		return nil, fmt.Errorf("byte array for multilinestring must be at least length 4")
+	count, err := d.readCount(buffer, "multilinestring")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.enter(buffer); err != nil {
+		return nil, err
 	}
-	count := binary.LittleEndian.Uint32(buffer.Next(4))
+	defer d.leave()
 
 	for i := 0; i < int(count); i++ {
-
+		d.at(i)
 		// we don't need these, as they are passed from parent
-		//bom := data[0]
-		buffer.Next(1)
-		//geometryType := data[1:5]
-		buffer.Next(4)
+		_, memberDimensions, err := d.readMemberType(buffer, "multilinestring", dimensions, LineStringType)
+		if err != nil {
+			return nil, err
+		}
 
-		lineString, err := LineStringFromEWKB(buffer, dimensions)
+		lineString, err := lineStringFromEWKB(buffer, memberDimensions, d)
 		if err != nil {
-			return nil, fmt.Errorf(err.Error())
+			return nil, err
 		}
+		forceDimensions(lineString, dimensions)
 
 		mls.LineStrings = append(mls.LineStrings, *lineString)
 	}
@@ -110,3 +152,50 @@ func (ml MultiLineString) GetEWKB(includeGeoType bool) bytes.Buffer {
 	}
 	return *buf
 }
+
+// Report whether the MultiLineString is valid, as ST_IsValid
+func (ml MultiLineString) IsValid() bool {
+	return ml.Validate() == nil
+}
+
+// Check the LineStrings of the MultiLineString are valid
+func (ml MultiLineString) Validate() error {
+	return validityError(ml.validate(), pathName(MultiLineStringType))
+}
+
+func (ml MultiLineString) validate() *ValidityError {
+	for i, l := range ml.LineStrings {
+		e := checkDimensions(l.Dimensions, ml.Dimensions, nil)
+		if e == nil {
+			e = l.validate()
+		}
+		if e != nil {
+			return e.at(i)
+		}
+	}
+	return nil
+}
+
+// Get the area of the MultiLineString, which has none
+func (mls MultiLineString) Area() float64 {
+	return 0
+}
+
+// Get the length of the MultiLineString, as ST_Length
+func (mls MultiLineString) Length() float64 {
+	total := 0.0
+	for _, ls := range mls.LineStrings {
+		total += ls.Length()
+	}
+	return total
+}
+
+// Get the perimeter of the MultiLineString, which has none
+func (mls MultiLineString) Perimeter() float64 {
+	return 0
+}
+
+// Get the minimum distance between the MultiLineString and another geometry, as ST_Distance
+func (mls MultiLineString) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&mls, other)
+}
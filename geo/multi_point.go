@@ -13,7 +13,7 @@ import (
 A MultiPoint is a collection of Points.
 */
 type MultiPoint struct {
-	Points     []Point
+	Coords     []float64
 	Dimensions Dimensions
 }
 
@@ -27,51 +27,117 @@ func (mp MultiPoint) String() string {
 	sb.WriteString("(MultiPoint ")
 	sb.WriteString(mp.Dimensions.String())
 	sb.WriteString(" [")
-	for _, p := range mp.Points {
-		sb.WriteString(p.String())
-		sb.WriteString(" ")
-	}
+	writeCoordsString(&sb, mp.Coords, mp.Dimensions)
 
 	sb.WriteString("])")
 	return sb.String()
 }
 
+// Report whether the MultiPoint equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (mp MultiPoint) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&mp, other, o.Tolerance)
+	}
+	mq, ok := subtypeAs[MultiPoint](other)
+	return ok && mp.Dimensions == mq.Dimensions && coordsWithin(mp.Coords, mq.Coords, o.Tolerance)
+}
+
 // Get the dimensions of the geometry
 func (mp MultiPoint) GetDimensions() Dimensions {
 	return mp.Dimensions
 }
 
+// Get the bounding box of the MultiPoint in each of its dimensions
+func (mp MultiPoint) Envelope() Envelope {
+	return coordsEnvelope(mp.Coords, mp.Dimensions)
+}
+
+// Get the number of points in the MultiPoint
+func (mp MultiPoint) NumPoints() int {
+	return coordCount(mp.Coords, mp.Dimensions)
+}
+
+// Get the ordinates of the point at index i. The slice shares storage with the MultiPoint.
+func (mp MultiPoint) Coord(i int) []float64 {
+	return coordAt(mp.Coords, mp.Dimensions, i)
+}
+
+// Get the point at index i. The point shares storage with the MultiPoint.
+func (mp MultiPoint) PointN(i int) Point {
+	return Point{Coords: mp.Coord(i), Dimensions: mp.Dimensions}
+}
+
+// Get the member points of the MultiPoint, each sharing storage with it
+func (mp MultiPoint) Points() []Point {
+	return pointsFromCoords(mp.Coords, mp.Dimensions)
+}
+
 // Create a MultiPoint from a slice of Points of the same dimensions.
 // Length must be at least 1
 func NewMultiPoint(p []Point) (*MultiPoint, error) {
-	mp := MultiPoint{}
-	mp.Points = p
 	if len(p) == 0 {
-		return nil, fmt.Errorf("error creating linestring, no points provided")
+		return nil, fmt.Errorf("error creating multipoint, no points provided")
 	}
-	mp.Dimensions = p[0].GetDimensions()
-	return &mp, nil
+	coords, err := flattenPoints(p, p[0].GetDimensions())
+	if err != nil {
+		return nil, fmt.Errorf("error creating multipoint, %v", err)
+	}
+	return MultiPointFromCoords(coords, p[0].GetDimensions())
+}
+
+// Create a MultiPoint from a flat slice of ordinates and Dimensions.
+// Length must be at least 1 point, and a whole number of points.
+func MultiPointFromCoords(coords []float64, dimensions Dimensions) (*MultiPoint, error) {
+	if err := checkCoords(coords, dimensions); err != nil {
+		return nil, fmt.Errorf("error creating multipoint, %v", err)
+	}
+	if len(coords) == 0 {
+		return nil, fmt.Errorf("error creating multipoint, no points provided")
+	}
+	return &MultiPoint{Coords: coords, Dimensions: dimensions}, nil
 }
 
-// Create a new MultiPoint from input byte buffer in EWKB format and dimensions.
-func MultiPointFromEWKB(buffer *bytes.Buffer, dimensions Dimensions) (*MultiPoint, error) {
+// Create a new MultiPoint from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func MultiPointFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*MultiPoint, error) {
+	d, err := newDecodeState(pathName(MultiPointType), buffer.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return multiPointFromEWKB(buffer, dimensions, d)
+}
+
+func multiPointFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, d *decodeState) (*MultiPoint, error) {
 
 	mp := MultiPoint{}
 	mp.Dimensions = dimensions
-	if buffer.Len() < 4 {
-		return nil, fmt.Errorf("byte array for multipoint must be at least length 4")
+	count, err := d.readCount(buffer, "multipoint")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.enter(buffer); err != nil {
+		return nil, err
 	}
-	count := binary.LittleEndian.Uint32(buffer.Next(4))
+	defer d.leave()
 
 	for i := 0; i < int(count); i++ {
-		buffer.Next(1) // Move past Byte Order Marker
-		buffer.Next(4) // Move past GeoType
+		d.at(i)
+		// Move past Byte Order Marker and GeoType
+		_, memberDimensions, err := d.readMemberType(buffer, "multipoint", dimensions, PointType)
+		if err != nil {
+			return nil, err
+		}
 
-		point, err := PointFromEWKB(buffer, dimensions)
+		if buffer.Len() < int(PointByteLength(memberDimensions)) {
+			return nil, d.errorf(buffer, "input for point is too short (%v) for requested dimensions %v", buffer.Len(), memberDimensions)
+		}
+		coords, err := d.readPoint(buffer, memberDimensions)
 		if err != nil {
-			return nil, fmt.Errorf(err.Error())
+			return nil, err
 		}
-		mp.Points = append(mp.Points, *point)
+		mp.Coords = append(mp.Coords, projectCoords(coords, memberDimensions, dimensions)...)
 	}
 
 	return &mp, nil
@@ -88,15 +154,44 @@ func (mp MultiPoint) GetEWKB(includeGeoType bool) bytes.Buffer {
 		buf.Write(geoTypeBytes)
 	}
 
-	lenBytes := binary.LittleEndian.AppendUint32([]byte{}, uint32(len(mp.Points)))
+	lenBytes := binary.LittleEndian.AppendUint32([]byte{}, uint32(mp.NumPoints()))
 	buf.Write(lenBytes)
 
-	for _, p := range mp.Points {
+	geoTypeBytes := encodeGeoType(PointType, false, mp.Dimensions)
+	for i := 0; i < mp.NumPoints(); i++ {
 		buf.WriteByte(byte(LittleEndian))
-		geoTypeBytes := encodeGeoType(PointType, false, mp.Dimensions)
 		buf.Write(geoTypeBytes)
-		pb := p.GetEWKB(false)
-		pb.WriteTo(buf)
+		writeCoords(buf, mp.Coord(i))
 	}
 	return *buf
 }
+
+// Report whether the MultiPoint is valid, as ST_IsValid
+func (mp MultiPoint) IsValid() bool {
+	return mp.Validate() == nil
+}
+
+// Check the points of the MultiPoint have finite ordinates, or are empty
+func (mp MultiPoint) Validate() error {
+	return validityError(validateCoords(mp.Coords, mp.Dimensions, true), pathName(MultiPointType))
+}
+
+// Get the area of the MultiPoint, which has none
+func (mp MultiPoint) Area() float64 {
+	return 0
+}
+
+// Get the length of the MultiPoint, which has none
+func (mp MultiPoint) Length() float64 {
+	return 0
+}
+
+// Get the perimeter of the MultiPoint, which has none
+func (mp MultiPoint) Perimeter() float64 {
+	return 0
+}
+
+// Get the minimum distance between the MultiPoint and another geometry, as ST_Distance
+func (mp MultiPoint) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&mp, other)
+}
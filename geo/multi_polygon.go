@@ -37,11 +37,39 @@ func (mp MultiPolygon) String() string {
 	return sb.String()
 }
 
+// Report whether the MultiPolygon equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (mp MultiPolygon) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&mp, other, o.Tolerance)
+	}
+	mq, ok := subtypeAs[MultiPolygon](other)
+	if !ok || mp.Dimensions != mq.Dimensions || len(mp.Polygons) != len(mq.Polygons) {
+		return false
+	}
+	for i, p := range mp.Polygons {
+		if !p.Equals(mq.Polygons[i], o) {
+			return false
+		}
+	}
+	return true
+}
+
 // Get the dimensions of the geometry
 func (mp MultiPolygon) GetDimensions() Dimensions {
 	return mp.Dimensions
 }
 
+// Get the bounding box of the MultiPolygon in each of its dimensions
+func (mp MultiPolygon) Envelope() Envelope {
+	e := NewEnvelope(mp.Dimensions)
+	for _, p := range mp.Polygons {
+		e.add(p.Envelope())
+	}
+	return e.clear()
+}
+
 // Create a MultiPolygon from a slice of Polygons of the same dimensions.
 // Length must be at least 1
 func NewMultiPolygon(p []Polygon) (*MultiPolygon, error) {
@@ -54,29 +82,42 @@ func NewMultiPolygon(p []Polygon) (*MultiPolygon, error) {
 	return &mp, nil
 }
 
-// Create a new MultiPoint from input byte buffer in EWKB format and dimensions.
-func MultiPolygonFromEWKB(buffer *bytes.Buffer, dimensions Dimensions) (*MultiPolygon, error) {
+// Create a new MultiPoint from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func MultiPolygonFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*MultiPolygon, error) {
+	d, err := newDecodeState(pathName(MultiPolygonType), buffer.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return multiPolygonFromEWKB(buffer, dimensions, d)
+}
+
+func multiPolygonFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, d *decodeState) (*MultiPolygon, error) {
 
 	multiPoly := MultiPolygon{}
 	multiPoly.Dimensions = dimensions
-	if buffer.Len() < 4 {
-		return nil, fmt.Errorf("byte array for multipolygon must be at least length 4")
+	count, err := d.readCount(buffer, "multipolygon")
+	if err != nil {
+		return nil, err
 	}
-
-	count := binary.LittleEndian.Uint32(buffer.Next(4))
+	if err := d.enter(buffer); err != nil {
+		return nil, err
+	}
+	defer d.leave()
 
 	for i := 0; i < int(count); i++ {
-
+		d.at(i)
 		// we don't need these as they are passed in from parent
-		//bom := data[0]
-		buffer.Next(1)
-		//geometryType := data[1:5]
-		buffer.Next(4)
+		_, memberDimensions, err := d.readMemberType(buffer, "multipolygon", dimensions, PolygonType)
+		if err != nil {
+			return nil, err
+		}
 
-		polygon, err := PolygonFromEWKB(buffer, dimensions)
+		polygon, err := polygonFromEWKB(buffer, memberDimensions, d)
 		if err != nil {
-			return nil, fmt.Errorf(err.Error())
+			return nil, err
 		}
+		forceDimensions(polygon, dimensions)
 
 		multiPoly.Polygons = append(multiPoly.Polygons, *polygon)
 	}
@@ -109,3 +150,91 @@ func (mp MultiPolygon) GetEWKB(includeGeoType bool) bytes.Buffer {
 	}
 	return *buf
 }
+
+// Report whether the MultiPolygon is valid, as ST_IsValid
+func (mp MultiPolygon) IsValid() bool {
+	return mp.Validate() == nil
+}
+
+// Check the Polygons of the MultiPolygon are valid, and do not cross,
+// overlap or lie within each other
+func (mp MultiPolygon) Validate() error {
+	return validityError(mp.validate(), pathName(MultiPolygonType))
+}
+
+func (mp MultiPolygon) validate() *ValidityError {
+	polygons := make([][][][2]float64, len(mp.Polygons))
+	for i, p := range mp.Polygons {
+		e := checkDimensions(p.Dimensions, mp.Dimensions, nil)
+		if e == nil {
+			e = p.validate()
+		}
+		if e != nil {
+			return e.at(i)
+		}
+		for _, ring := range p.LinearRings {
+			polygons[i] = append(polygons[i], distinctXY(ring.Coords, ring.Dimensions))
+		}
+	}
+
+	for i := range polygons {
+		for j := i + 1; j < len(polygons); j++ {
+			for _, a := range polygons[i] {
+				for _, b := range polygons[j] {
+					if e := validateRingCrossings(a, b); e != nil {
+						return e.at(j)
+					}
+				}
+			}
+		}
+	}
+
+	// A shell within another polygon must lie within one of its holes
+	for i, p := range polygons {
+		for j, q := range polygons {
+			if i == j || len(p) == 0 || len(q) == 0 {
+				continue
+			}
+			point := testPoint(q[0], p[0])
+			if locateInRing(point, p[0]) != inside {
+				continue
+			}
+			inHole := false
+			for _, hole := range p[1:] {
+				inHole = inHole || locateInRing(point, hole) != outside
+			}
+			if !inHole {
+				return invalid("Nested shells", point[:]).at(j)
+			}
+		}
+	}
+	return nil
+}
+
+// Get the area of the MultiPolygon, as ST_Area
+func (mp MultiPolygon) Area() float64 {
+	total := 0.0
+	for _, p := range mp.Polygons {
+		total += p.Area()
+	}
+	return total
+}
+
+// Get the length of the MultiPolygon, which has none
+func (mp MultiPolygon) Length() float64 {
+	return 0
+}
+
+// Get the perimeter of the MultiPolygon, as ST_Perimeter
+func (mp MultiPolygon) Perimeter() float64 {
+	total := 0.0
+	for _, p := range mp.Polygons {
+		total += p.Perimeter()
+	}
+	return total
+}
+
+// Get the minimum distance between the MultiPolygon and another geometry, as ST_Distance
+func (mp MultiPolygon) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&mp, other)
+}
@@ -36,11 +36,28 @@ func (ms MultiSurface) String() string {
 	return sb.String()
 }
 
+// Report whether the MultiSurface equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (ms MultiSurface) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&ms, other, o.Tolerance)
+	}
+	mt, ok := subtypeAs[MultiSurface](other)
+	return ok && ms.Dimensions == mt.Dimensions && membersEqual(ms.Geometry, mt.Geometry, o)
+}
+
 // Get the dimensions of the geometry
 func (ms MultiSurface) GetDimensions() Dimensions {
 	return ms.Dimensions
 }
 
+// Get the bounding box of the MultiSurface in each of its dimensions, bounding its
+// arcs rather than their control points
+func (ms MultiSurface) Envelope() Envelope {
+	return membersEnvelope(ms.Geometry, ms.Dimensions)
+}
+
 // Create a MultiSurface from a slice of Geometry of the same dimensions.
 // [Must contain ]
 // Length must be at least 1
@@ -71,45 +88,54 @@ func (ms *MultiSurface) AddCurvePolygon(cp *CurvePolygon) error {
 	return nil
 }
 
-// Create a new MultiSurface from input byte buffer in EWKB format and dimensions.
-func MultiSurfaceFromEWKB(buffer *bytes.Buffer, dimensions Dimensions) (*MultiSurface, error) {
+// Create a new MultiSurface from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func MultiSurfaceFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*MultiSurface, error) {
+	d, err := newDecodeState(pathName(MultiSurfaceType), buffer.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return multiSurfaceFromEWKB(buffer, dimensions, d)
+}
+
+func multiSurfaceFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, d *decodeState) (*MultiSurface, error) {
 
 	multiSurface := MultiSurface{}
 	multiSurface.Dimensions = dimensions
-	if buffer.Len() < 4 {
-		return nil, fmt.Errorf("byte array for multisurface must be at least length 4")
+	count, err := d.readCount(buffer, "multisurface")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.enter(buffer); err != nil {
+		return nil, err
 	}
-	count := binary.LittleEndian.Uint32(buffer.Next(4))
+	defer d.leave()
 
 	for i := 0; i < int(count); i++ {
-		buffer.Next(1) // move past BOM
-		geoTypeBytes := buffer.Next(4)
-		geoType := GISGeometryType(binary.LittleEndian.Uint16(geoTypeBytes)) // 4 bytes for geometry type
-		flags := geoTypeBytes[3]                                             // flags are stored on the 3rd byte
-
-		if flags&byte(wkbSRID) == byte(wkbSRID) {
-			//SRID := binary.LittleEndian.Uint16(data[:4])
-			buffer.Next(4) // move past SRID
+		d.at(i)
+		// move past BOM, geometry type and any SRID
+		geoType, memberDimensions, err := d.readMemberType(buffer, "multisurface", dimensions, PolygonType, CurvePolygonType)
+		if err != nil {
+			return nil, err
 		}
+		d.push(pathName(geoType))
 
 		var geometry GeometrySubtype
-		var err error
 
 		switch geoType {
 		case PolygonType:
-			geometry, err = PolygonFromEWKB(buffer, dimensions)
+			geometry, err = polygonFromEWKB(buffer, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
 		case CurvePolygonType:
-			geometry, err = CurvePolygonFromEWKB(buffer, dimensions)
+			geometry, err = curvePolygonFromEWKB(buffer, memberDimensions, d)
 			if err != nil {
 				return nil, err
 			}
-		default:
-			fmt.Printf("multisurface must only contain polygons / curvepolygon: %v", geoType)
-
 		}
+		forceDimensions(geometry, dimensions)
+		d.pop()
 		multiSurface.Geometry = append(multiSurface.Geometry, geometry)
 
 	}
@@ -149,3 +175,45 @@ func (ms MultiSurface) GetEWKB(includeGeoType bool) bytes.Buffer {
 	}
 	return *buf
 }
+
+// Report whether the MultiSurface is valid, as ST_IsValid
+func (ms MultiSurface) IsValid() bool {
+	return ms.Validate() == nil
+}
+
+// Check the members of the MultiSurface are valid Polygons and CurvePolygons
+func (ms MultiSurface) Validate() error {
+	e := validateMemberTypes(ms.Geometry, "MultiSurface", PolygonType, CurvePolygonType)
+	if e == nil {
+		e = validateMembers(ms.Geometry, ms.Dimensions)
+	}
+	return validityError(e, pathName(MultiSurfaceType))
+}
+
+// Get the area of the MultiSurface, as ST_Area
+func (ms MultiSurface) Area() float64 {
+	total := 0.0
+	for _, surface := range ms.Geometry {
+		total += surface.Area()
+	}
+	return total
+}
+
+// Get the length of the MultiSurface, which has none
+func (ms MultiSurface) Length() float64 {
+	return 0
+}
+
+// Get the perimeter of the MultiSurface, as ST_Perimeter
+func (ms MultiSurface) Perimeter() float64 {
+	total := 0.0
+	for _, surface := range ms.Geometry {
+		total += surface.Perimeter()
+	}
+	return total
+}
+
+// Get the minimum distance between the MultiSurface and another geometry, as ST_Distance
+func (ms MultiSurface) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&ms, other)
+}
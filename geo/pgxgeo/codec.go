@@ -0,0 +1,167 @@
+/*
+Package pgxgeo registers the PostGIS geometry and geography types with pgx v5,
+so GISGeometry, GISGeography and EWKBView values can be used as query arguments
+and scan targets on a pgx connection in both the text (hex EWKB) and binary
+(EWKB) wire formats.
+
+PostGIS types are created by the extension and have no fixed OIDs, so they must
+be registered on each connection, for example from a pgxpool AfterConnect hook:
+
+	config.AfterConnect = pgxgeo.Register
+*/
+package pgxgeo
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+// Register the PostGIS geometry and geography types on a connection, looking up
+// their OIDs from the database.
+func Register(ctx context.Context, conn *pgx.Conn) error {
+	var geometryOID, geographyOID uint32
+	err := conn.QueryRow(ctx, "SELECT 'geometry'::regtype::oid, 'geography'::regtype::oid").Scan(&geometryOID, &geographyOID)
+	if err != nil {
+		return fmt.Errorf("unable to find postgis type oids: %w", err)
+	}
+	RegisterTypes(conn.TypeMap(), geometryOID, geographyOID)
+	return nil
+}
+
+// Register the PostGIS geometry and geography types on a type map with known OIDs
+func RegisterTypes(m *pgtype.Map, geometryOID uint32, geographyOID uint32) {
+	m.RegisterType(&pgtype.Type{Name: "geometry", OID: geometryOID, Codec: Codec{}})
+	m.RegisterType(&pgtype.Type{Name: "geography", OID: geographyOID, Codec: Codec{Geography: true}})
+}
+
+/*
+Codec is a pgtype.Codec for PostGIS geometry and geography values. The text
+format is hex encoded EWKB, and the binary format is EWKB.
+
+Any value implementing encoding.BinaryMarshaler (GISGeometry, GISGeography) can be
+encoded, and any target implementing encoding.BinaryUnmarshaler (*GISGeometry,
+*GISGeography, *EWKBView) can be scanned.
+*/
+type Codec struct {
+	Geography bool // Decode values as GISGeography rather than GISGeometry
+}
+
+func (Codec) FormatSupported(format int16) bool {
+	return format == pgtype.TextFormatCode || format == pgtype.BinaryFormatCode
+}
+
+func (Codec) PreferredFormat() int16 {
+	return pgtype.BinaryFormatCode
+}
+
+func (Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	if _, ok := value.(encoding.BinaryMarshaler); !ok {
+		return nil
+	}
+	switch format {
+	case pgtype.BinaryFormatCode:
+		return encodePlanBinary{}
+	case pgtype.TextFormatCode:
+		return encodePlanText{}
+	}
+	return nil
+}
+
+type encodePlanBinary struct{}
+
+func (encodePlanBinary) Encode(value any, buf []byte) ([]byte, error) {
+	ewkb, err := value.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, ewkb...), nil
+}
+
+type encodePlanText struct{}
+
+func (encodePlanText) Encode(value any, buf []byte) ([]byte, error) {
+	ewkb, err := value.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, hex.EncodeToString(ewkb)...), nil
+}
+
+func (Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if _, ok := target.(encoding.BinaryUnmarshaler); !ok {
+		return nil
+	}
+	switch format {
+	case pgtype.BinaryFormatCode:
+		return scanPlanBinary{}
+	case pgtype.TextFormatCode:
+		return scanPlanText{}
+	}
+	return nil
+}
+
+type scanPlanBinary struct{}
+
+func (scanPlanBinary) Scan(src []byte, target any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", target)
+	}
+	return target.(encoding.BinaryUnmarshaler).UnmarshalBinary(src)
+}
+
+type scanPlanText struct{}
+
+func (scanPlanText) Scan(src []byte, target any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", target)
+	}
+	ewkb, err := hex.DecodeString(string(src))
+	if err != nil {
+		return err
+	}
+	return target.(encoding.BinaryUnmarshaler).UnmarshalBinary(ewkb)
+}
+
+// Returns hex encoded EWKB, as expected by the GISGeometry sql.Scanner
+func (Codec) DecodeDatabaseSQLValue(m *pgtype.Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+	if format == pgtype.BinaryFormatCode {
+		return []byte(hex.EncodeToString(src)), nil
+	}
+	return append([]byte(nil), src...), nil
+}
+
+func (c Codec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+	var target encoding.BinaryUnmarshaler = &geo.GISGeometry{}
+	if c.Geography {
+		target = &geo.GISGeography{}
+	}
+
+	var plan pgtype.ScanPlan = scanPlanBinary{}
+	if format == pgtype.TextFormatCode {
+		plan = scanPlanText{}
+	}
+	if err := plan.Scan(src, target); err != nil {
+		return nil, err
+	}
+
+	switch t := target.(type) {
+	case *geo.GISGeography:
+		return *t, nil
+	case *geo.GISGeometry:
+		return *t, nil
+	}
+	return target, nil
+}
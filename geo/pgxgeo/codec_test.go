@@ -0,0 +1,185 @@
+package pgxgeo_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stephenirven/go-postgis/geo"
+	"github.com/stephenirven/go-postgis/geo/pgxgeo"
+)
+
+const (
+	geometryOID  = 17000 // PostGIS OIDs are assigned when the extension is created
+	geographyOID = 17001
+
+	// SELECT 'SRID=4326;POINT(1 2)'::geometry
+	capturedPoint = "0101000020E6100000000000000000F03F0000000000000040"
+	// SELECT 'SRID=4326;POINT(200 2)'::geometry
+	capturedOutOfRangePoint = "0101000020E610000000000000000069400000000000000040"
+)
+
+func TestCodecScan(t *testing.T) {
+
+	m := makeTestMap()
+	expected := makeTestGISPoint(t)
+
+	binary, err := hex.DecodeString(capturedPoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		src := []byte(capturedPoint)
+		if format == pgtype.BinaryFormatCode {
+			src = binary
+		}
+
+		var g geo.GISGeometry
+		err = m.PlanScan(geometryOID, format, &g).Scan(src, &g)
+		if err != nil {
+			t.Error(err)
+		}
+		if !cmp.Equal(expected, g) {
+			t.Errorf("scanned geometry %v was not equal to %v", g, expected)
+		}
+
+		value, err := pgxgeo.Codec{}.DecodeValue(m, geometryOID, format, src)
+		if err != nil {
+			t.Error(err)
+		}
+		if !cmp.Equal(expected, value) {
+			t.Errorf("decoded geometry %v was not equal to %v", value, expected)
+		}
+
+		var geography geo.GISGeography
+		err = m.PlanScan(geographyOID, format, &geography).Scan(src, &geography)
+		if err != nil {
+			t.Error(err)
+		}
+		if !cmp.Equal(expected, geography.GISGeometry) {
+			t.Errorf("scanned geography %v was not equal to %v", geography, expected)
+		}
+
+		err = m.PlanScan(geometryOID, format, &g).Scan(nil, &g)
+		if err == nil {
+			t.Error("scanning NULL into geometry did not return an error")
+		}
+	}
+}
+
+func TestCodecScanView(t *testing.T) {
+
+	m := makeTestMap()
+
+	src, err := hex.DecodeString(capturedPoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var view geo.EWKBView
+	err = m.PlanScan(geometryOID, pgtype.BinaryFormatCode, &view).Scan(src, &view)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// pgx reuses the source buffer, the view must hold its own copy
+	src[0] = 0xff
+	if view.GeoType() != geo.PointType || view.SRID() != 4326 {
+		t.Errorf("view type %v srid %v was not PointType 4326", view.GeoType(), view.SRID())
+	}
+}
+
+func TestCodecEncode(t *testing.T) {
+
+	m := makeTestMap()
+	g := makeTestGISPoint(t)
+
+	buf, err := m.Encode(geometryOID, pgtype.TextFormatCode, g, nil)
+	if err != nil {
+		t.Error(err)
+	}
+	if !strings.EqualFold(string(buf), capturedPoint) {
+		t.Errorf("encoded text %s was not %v", buf, capturedPoint)
+	}
+
+	buf, err = m.Encode(geometryOID, pgtype.BinaryFormatCode, &g, nil)
+	if err != nil {
+		t.Error(err)
+	}
+	if !strings.EqualFold(hex.EncodeToString(buf), capturedPoint) {
+		t.Errorf("encoded binary %x was not %v", buf, capturedPoint)
+	}
+
+	geography := geo.GISGeography{GISGeometry: g}
+	buf, err = m.Encode(geographyOID, pgtype.BinaryFormatCode, geography, nil)
+	if err != nil {
+		t.Error(err)
+	}
+	if !strings.EqualFold(hex.EncodeToString(buf), capturedPoint) {
+		t.Errorf("encoded geography %x was not %v", buf, capturedPoint)
+	}
+}
+
+func TestCodecGeographyRange(t *testing.T) {
+
+	m := makeTestMap()
+
+	var geography geo.GISGeography
+	err := m.PlanScan(geographyOID, pgtype.TextFormatCode, &geography).Scan([]byte(capturedOutOfRangePoint), &geography)
+	if err == nil {
+		t.Error("scanning geography with longitude 200 did not return an error")
+	}
+
+	var g geo.GISGeometry
+	err = m.PlanScan(geometryOID, pgtype.TextFormatCode, &g).Scan([]byte(capturedOutOfRangePoint), &g)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = m.Encode(geographyOID, pgtype.BinaryFormatCode, geo.GISGeography{GISGeometry: g}, nil)
+	if err == nil {
+		t.Error("encoding geography with longitude 200 did not return an error")
+	}
+}
+
+func makeTestMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	pgxgeo.RegisterTypes(m, geometryOID, geographyOID)
+	return m
+}
+
+func makeTestGISPoint(t *testing.T) geo.GISGeometry {
+	point, err := geo.NewPoint([]float64{1, 2}, geo.XY)
+	if err != nil {
+		t.Error(err)
+	}
+	g := geo.NewGISGeometry(point)
+	g.SetSRID(4326)
+	return g
+}
+
+func TestCodecScanNullable(t *testing.T) {
+
+	m := makeTestMap()
+	expected := makeTestGISPoint(t)
+
+	// Nullable columns generated by the sqlc pgx/v5 target scan into pointers
+	var g *geo.GISGeometry
+	err := m.PlanScan(geometryOID, pgtype.TextFormatCode, &g).Scan([]byte(capturedPoint), &g)
+	if err != nil {
+		t.Error(err)
+	}
+	if g == nil || !cmp.Equal(expected, *g) {
+		t.Errorf("scanned geometry %v was not equal to %v", g, expected)
+	}
+
+	err = m.PlanScan(geometryOID, pgtype.TextFormatCode, &g).Scan(nil, &g)
+	if err != nil {
+		t.Error(err)
+	}
+	if g != nil {
+		t.Errorf("scanned null geometry was %v", g)
+	}
+}
@@ -2,9 +2,9 @@ package geo
 
 import (
 	"bytes"
-	"encoding/binary"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 )
 
@@ -29,11 +29,27 @@ func (p Point) GetDimensions() Dimensions {
 	return p.Dimensions
 }
 
+// Get the bounding box of the Point in each of its dimensions
+func (p Point) Envelope() Envelope {
+	return coordsEnvelope(p.Coords, p.Dimensions)
+}
+
 // Stringer interface
 func (p Point) String() string {
 	return "(" + p.Dimensions.String() + " point: [" + strings.Join(strings.Fields(fmt.Sprint(p.Coords)), ",") + "])"
 }
 
+// Report whether the Point equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (p Point) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&p, other, o.Tolerance)
+	}
+	q, ok := subtypeAs[Point](other)
+	return ok && p.Dimensions == q.Dimensions && coordsWithin(p.Coords, q.Coords, o.Tolerance)
+}
+
 // Returns the expected byte length for a point of given dimensions
 func PointByteLength(dimensions Dimensions) uint32 {
 	switch dimensions {
@@ -53,8 +69,11 @@ func PointByteLength(dimensions Dimensions) uint32 {
 // Create a Point from a slice of float64 and Dimensions. Length of slice must correspond
 // with dimensions.
 func NewPoint(c []float64, dimensions Dimensions) (*Point, error) {
-	if len(c) < len(fmt.Sprintf("%v", dimensions)) {
-		return nil, fmt.Errorf("point of %v dimensions needs length %v, %v provided", dimensions, PointByteLength(dimensions), len(c))
+	if dimensions.Stride() == 0 {
+		return nil, fmt.Errorf("unsupported dimensions %v", dimensions)
+	}
+	if len(c) != dimensions.Stride() {
+		return nil, fmt.Errorf("point of %v dimensions needs %v ordinates, %v provided", dimensions, dimensions.Stride(), len(c))
 	}
 
 	return &Point{
@@ -63,19 +82,27 @@ func NewPoint(c []float64, dimensions Dimensions) (*Point, error) {
 	}, nil
 }
 
-// Create a new Point from input byte buffer in EWKB format and dimensions.
-func PointFromEWKB(buffer *bytes.Buffer, dimensions Dimensions) (*Point, error) {
+// Create a new Point from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func PointFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*Point, error) {
+	d, err := newDecodeState(pathName(PointType), buffer.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return pointFromEWKB(buffer, dimensions, d)
+}
+
+func pointFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, d *decodeState) (*Point, error) {
 
 	if buffer.Len() < int(PointByteLength(dimensions)) {
-		return nil, fmt.Errorf("input for point is too short (%v) for requested dimensions %v", buffer.Len(), dimensions)
+		return nil, d.errorf(buffer, "input for point is too short (%v) for requested dimensions %v", buffer.Len(), dimensions)
 	}
-	p := Point{}
-	p.Dimensions = dimensions
-	for i := 0; i < len(string(fmt.Sprintf("%v", p.Dimensions))); i++ {
-		p.Coords = append(p.Coords, math.Float64frombits(binary.LittleEndian.Uint64(buffer.Next(8))))
+	coords, err := d.readPoint(buffer, dimensions)
+	if err != nil {
+		return nil, err
 	}
 
-	return &p, nil
+	return &Point{Coords: coords, Dimensions: dimensions}, nil
 }
 
 // Get a byte slice containing the EKWB representation of the geometry
@@ -90,9 +117,51 @@ func (p Point) GetEWKB(includeGeoType bool) bytes.Buffer {
 	}
 
 	// Point encoding is a simple concatenation of float64 bits
+	writeCoords(buf, p.Coords)
+	return *buf
+}
+
+// Report whether the Point is valid, as ST_IsValid
+func (p Point) IsValid() bool {
+	return p.Validate() == nil
+}
+
+// Check the Point has finite ordinates for its dimensions, or is empty
+func (p Point) Validate() error {
+	return validityError(p.validate(), pathName(PointType))
+}
+
+func (p Point) validate() *ValidityError {
+	if len(p.Coords) != p.Dimensions.Stride() {
+		return invalid("Invalid Coordinate: "+strconv.Itoa(len(p.Coords))+" ordinates for dimensions "+p.Dimensions.String(), nil)
+	}
+	if pointEmpty(p.Coords) {
+		return nil
+	}
 	for _, c := range p.Coords {
-		bits := binary.LittleEndian.AppendUint64([]byte{}, math.Float64bits(c))
-		buf.Write(bits)
+		if math.IsNaN(c) || math.IsInf(c, 0) {
+			return invalid("Invalid Coordinate", p.Coords)
+		}
 	}
-	return *buf
+	return nil
+}
+
+// Get the area of the Point, which has none
+func (p Point) Area() float64 {
+	return 0
+}
+
+// Get the length of the Point, which has none
+func (p Point) Length() float64 {
+	return 0
+}
+
+// Get the perimeter of the Point, which has none
+func (p Point) Perimeter() float64 {
+	return 0
+}
+
+// Get the minimum distance between the Point and another geometry, as ST_Distance
+func (p Point) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&p, other)
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -38,39 +39,84 @@ func (p Polygon) String() string {
 	return sb.String()
 }
 
+// Report whether the Polygon equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (p Polygon) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&p, other, o.Tolerance)
+	}
+	q, ok := subtypeAs[Polygon](other)
+	if !ok || p.Dimensions != q.Dimensions || len(p.LinearRings) != len(q.LinearRings) {
+		return false
+	}
+	for i, ring := range p.LinearRings {
+		if !ring.Equals(q.LinearRings[i], o) {
+			return false
+		}
+	}
+	return true
+}
+
 // Get the dimensions of the geometry
 func (p Polygon) GetDimensions() Dimensions {
 	return p.Dimensions
 }
 
+// Get the bounding box of the Polygon in each of its dimensions
+func (p Polygon) Envelope() Envelope {
+	e := NewEnvelope(p.Dimensions)
+	for _, ring := range p.LinearRings {
+		e.addCoords(ring.Coords)
+	}
+	return e.clear()
+}
+
 // Create a Polygon from a slice of LinearRings of the same dimensions.
 func NewPolygon(l []LinearRing) (*Polygon, error) {
+	if len(l) == 0 {
+		return nil, fmt.Errorf("error creating polygon, no linearrings provided")
+	}
 	p := Polygon{}
 	p.LinearRings = l
 	p.Dimensions = l[0].GetDimensions()
+	for i, ring := range l {
+		if ring.Dimensions != p.Dimensions {
+			return nil, fmt.Errorf("error creating polygon, linearring %v has dimensions %v, expected %v", i, ring.Dimensions, p.Dimensions)
+		}
+	}
 	return &p, nil
 }
 
-// Create a new Polygon from input byte buffer in EWKB format and dimensions.
-func PolygonFromEWKB(buffer *bytes.Buffer, dimensions Dimensions) (*Polygon, error) {
-	poly := Polygon{}
-	poly.Dimensions = dimensions
-	if buffer.Len() < 4 {
-		return nil, fmt.Errorf("byte array for polygon must be at least length 4")
+// Create a new Polygon from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func PolygonFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*Polygon, error) {
+	d, err := newDecodeState(pathName(PolygonType), buffer.Len(), opts)
+	if err != nil {
+		return nil, err
 	}
-	count := binary.LittleEndian.Uint32(buffer.Next(4))
+	return polygonFromEWKB(buffer, dimensions, d)
+}
 
-	if count < 1 {
-		return nil, fmt.Errorf("polygons must have at least one linearring, %v provided", count)
+func polygonFromEWKB(buffer *bytes.Buffer, dimensions Dimensions, d *decodeState) (*Polygon, error) {
+	poly := Polygon{}
+	poly.Dimensions = dimensions
+	count, err := d.readCount(buffer, "polygon")
+	if err != nil {
+		return nil, err
 	}
 
+	// An empty polygon has no linearrings
+	d.push("ring")
 	for i := 0; i < int(count); i++ {
-		linearRing, err := LinearRingFromEWKB(buffer, dimensions)
+		d.at(i)
+		linearRing, err := linearRingFromEWKB(buffer, dimensions, d)
 		if err != nil {
-			return nil, fmt.Errorf(err.Error())
+			return nil, err
 		}
 		poly.LinearRings = append(poly.LinearRings, *linearRing)
 	}
+	d.pop()
 
 	return &poly, nil
 }
@@ -99,3 +145,100 @@ func (p Polygon) GetEWKB(includeGeoType bool) bytes.Buffer {
 	}
 	return *buf
 }
+
+// Report whether the Polygon is valid, as ST_IsValid
+func (p Polygon) IsValid() bool {
+	return p.Validate() == nil
+}
+
+// Check the rings of the Polygon are valid and do not cross or overlap, and
+// that its holes lie within its shell and not within each other
+func (p Polygon) Validate() error {
+	return validityError(p.validate(), pathName(PolygonType))
+}
+
+func (p Polygon) validate() *ValidityError {
+	if e := p.validateStructure(); e != nil {
+		return e
+	}
+
+	rings := make([][][2]float64, len(p.LinearRings))
+	for i, ring := range p.LinearRings {
+		if e := validateRing(ring.Coords, ring.Dimensions); e != nil {
+			return e.in("ring[" + strconv.Itoa(i) + "]")
+		}
+		rings[i] = distinctXY(ring.Coords, ring.Dimensions)
+	}
+
+	for i := range rings {
+		for j := i + 1; j < len(rings); j++ {
+			if e := validateRingCrossings(rings[i], rings[j]); e != nil {
+				return e.in("ring[" + strconv.Itoa(j) + "]")
+			}
+		}
+	}
+	for i := 1; i < len(rings); i++ {
+		if q := testPoint(rings[i], rings[0]); locateInRing(q, rings[0]) == outside {
+			return invalid("Hole lies outside shell", q[:]).in("ring[" + strconv.Itoa(i) + "]")
+		}
+		for j := 1; j < len(rings); j++ {
+			if q := testPoint(rings[j], rings[i]); i != j && locateInRing(q, rings[i]) == inside {
+				return invalid("Holes are nested", q[:]).in("ring[" + strconv.Itoa(j) + "]")
+			}
+		}
+	}
+	return nil
+}
+
+// Check the rings of the Polygon have its dimensions, are closed and have at
+// least 4 points, without checking them in the XY plane
+func (p Polygon) validateStructure() *ValidityError {
+	for i, ring := range p.LinearRings {
+		e := checkDimensions(ring.Dimensions, p.Dimensions, nil)
+		if e == nil {
+			e = validateRingStructure(ring.Coords, ring.Dimensions)
+		}
+		if e != nil {
+			return e.in("ring[" + strconv.Itoa(i) + "]")
+		}
+	}
+	return nil
+}
+
+// Get the area of the Polygon, that of its shell less that of its holes, as ST_Area
+func (p Polygon) Area() float64 {
+	return ringsArea(p.LinearRings, ringArea)
+}
+
+// Get the length of the Polygon, which has none
+func (p Polygon) Length() float64 {
+	return 0
+}
+
+// Get the perimeter of the Polygon, the length of all its rings, as ST_Perimeter
+func (p Polygon) Perimeter() float64 {
+	total := 0.0
+	for _, ring := range p.LinearRings {
+		total += polylineLength(ring.Coords, ring.Dimensions)
+	}
+	return total
+}
+
+// Get the area of the Polygon using Z, as ST_3DArea
+func (p Polygon) Area3D() float64 {
+	return ringsArea(p.LinearRings, ringArea3D)
+}
+
+// Get the perimeter of the Polygon using Z, as ST_3DPerimeter
+func (p Polygon) Perimeter3D() float64 {
+	total := 0.0
+	for _, ring := range p.LinearRings {
+		total += polylineLength3D(ring.Coords, ring.Dimensions)
+	}
+	return total
+}
+
+// Get the minimum distance between the Polygon and another geometry, as ST_Distance
+func (p Polygon) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&p, other)
+}
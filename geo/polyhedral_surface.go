@@ -38,11 +38,39 @@ func (p PolyHedralSurface) String() string {
 	return sb.String()
 }
 
+// Report whether the PolyHedralSurface equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (p PolyHedralSurface) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&p, other, o.Tolerance)
+	}
+	q, ok := subtypeAs[PolyHedralSurface](other)
+	if !ok || p.Dimensions != q.Dimensions || len(p.Polygons) != len(q.Polygons) {
+		return false
+	}
+	for i, polygon := range p.Polygons {
+		if !polygon.Equals(q.Polygons[i], o) {
+			return false
+		}
+	}
+	return true
+}
+
 // Get the dimensions of the geometry
 func (p PolyHedralSurface) GetDimensions() Dimensions {
 	return p.Dimensions
 }
 
+// Get the bounding box of the PolyHedralSurface in each of its dimensions
+func (p PolyHedralSurface) Envelope() Envelope {
+	e := NewEnvelope(p.Dimensions)
+	for _, polygon := range p.Polygons {
+		e.add(polygon.Envelope())
+	}
+	return e.clear()
+}
+
 // Create a PolyhedralSurface from a slice of Polygons of the same dimensions.
 // Length must be at least 1
 func NewPolyhedralSurface(p []Polygon) (*PolyHedralSurface, error) {
@@ -55,28 +83,42 @@ func NewPolyhedralSurface(p []Polygon) (*PolyHedralSurface, error) {
 	return &poly, nil
 }
 
-// Create a new PolyhedralSurface from input byte buffer in EWKB format and dimensions.
-func PolyhedralSurfaceFromEWKB(b *bytes.Buffer, dimensions Dimensions) (*PolyHedralSurface, error) {
+// Create a new PolyhedralSurface from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func PolyhedralSurfaceFromEWKB(b *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*PolyHedralSurface, error) {
+	d, err := newDecodeState(pathName(PolyHedralSurfaceType), b.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return polyhedralSurfaceFromEWKB(b, dimensions, d)
+}
+
+func polyhedralSurfaceFromEWKB(b *bytes.Buffer, dimensions Dimensions, d *decodeState) (*PolyHedralSurface, error) {
 
 	polyhedralSurface := PolyHedralSurface{}
 	polyhedralSurface.Dimensions = dimensions
-	if b.Len() < 4 {
-		return nil, fmt.Errorf("byte array for polyhedralsurface must be at least length 4")
+	count, err := d.readCount(b, "polyhedralsurface")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.enter(b); err != nil {
+		return nil, err
 	}
-	count := binary.LittleEndian.Uint32(b.Next(4))
+	defer d.leave()
 
 	for i := 0; i < int(count); i++ {
-
+		d.at(i)
 		// We don't need these
-		//bom := data[0]
-		b.Next(1)
-		//geometryType := GISGeometryType(binary.LittleEndian.Uint16(data[1:5]))
-		b.Next(4)
+		_, memberDimensions, err := d.readMemberType(b, "polyhedralsurface", dimensions, PolygonType)
+		if err != nil {
+			return nil, err
+		}
 
-		polygon, err := PolygonFromEWKB(b, dimensions)
+		polygon, err := polygonFromEWKB(b, memberDimensions, d)
 		if err != nil {
-			return nil, fmt.Errorf(err.Error())
+			return nil, err
 		}
+		forceDimensions(polygon, dimensions)
 		polyhedralSurface.Polygons = append(polyhedralSurface.Polygons, *polygon)
 	}
 
@@ -106,3 +148,73 @@ func (ps PolyHedralSurface) GetEWKB(includeGeoType bool) bytes.Buffer {
 	}
 	return *buf
 }
+
+// Report whether the PolyHedralSurface is valid, as ST_IsValid
+func (ps PolyHedralSurface) IsValid() bool {
+	return ps.Validate() == nil
+}
+
+// Check the faces of the PolyHedralSurface have closed rings of at least 4
+// points. Faces are not checked in the XY plane.
+func (ps PolyHedralSurface) Validate() error {
+	return validityError(ps.validate(), pathName(PolyHedralSurfaceType))
+}
+
+func (ps PolyHedralSurface) validate() *ValidityError {
+	for i, p := range ps.Polygons {
+		e := checkDimensions(p.Dimensions, ps.Dimensions, nil)
+		if e == nil {
+			e = p.validateStructure()
+		}
+		if e != nil {
+			return e.at(i)
+		}
+	}
+	return nil
+}
+
+// Get the area of the PolyHedralSurface, as ST_Area
+func (p PolyHedralSurface) Area() float64 {
+	total := 0.0
+	for _, polygon := range p.Polygons {
+		total += polygon.Area()
+	}
+	return total
+}
+
+// Get the length of the PolyHedralSurface, which has none
+func (p PolyHedralSurface) Length() float64 {
+	return 0
+}
+
+// Get the perimeter of the PolyHedralSurface, as ST_Perimeter
+func (p PolyHedralSurface) Perimeter() float64 {
+	total := 0.0
+	for _, polygon := range p.Polygons {
+		total += polygon.Perimeter()
+	}
+	return total
+}
+
+// Get the area of the PolyHedralSurface using Z, as ST_3DArea
+func (p PolyHedralSurface) Area3D() float64 {
+	total := 0.0
+	for _, polygon := range p.Polygons {
+		total += polygon.Area3D()
+	}
+	return total
+}
+
+// Get the perimeter of the PolyHedralSurface using Z, as ST_3DPerimeter
+func (p PolyHedralSurface) Perimeter3D() float64 {
+	total := 0.0
+	for _, polygon := range p.Polygons {
+		total += polygon.Perimeter3D()
+	}
+	return total
+}
+
+// Get the minimum distance between the PolyHedralSurface and another geometry, as ST_Distance
+func (p PolyHedralSurface) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&p, other)
+}
@@ -0,0 +1,176 @@
+package raster
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Pixel type of a raster band, from the low 4 bits of the band flags
+type PixelType byte
+
+const (
+	Pixel1BB   PixelType = 0  // 1-bit boolean
+	Pixel2BUI  PixelType = 1  // 2-bit unsigned integer
+	Pixel4BUI  PixelType = 2  // 4-bit unsigned integer
+	Pixel8BSI  PixelType = 3  // 8-bit signed integer
+	Pixel8BUI  PixelType = 4  // 8-bit unsigned integer
+	Pixel16BSI PixelType = 5  // 16-bit signed integer
+	Pixel16BUI PixelType = 6  // 16-bit unsigned integer
+	Pixel32BSI PixelType = 7  // 32-bit signed integer
+	Pixel32BUI PixelType = 8  // 32-bit unsigned integer
+	Pixel32BF  PixelType = 10 // 32-bit float
+	Pixel64BF  PixelType = 11 // 64-bit float
+)
+
+func (p PixelType) String() string {
+	switch p {
+	case Pixel1BB:
+		return "1BB"
+	case Pixel2BUI:
+		return "2BUI"
+	case Pixel4BUI:
+		return "4BUI"
+	case Pixel8BSI:
+		return "8BSI"
+	case Pixel8BUI:
+		return "8BUI"
+	case Pixel16BSI:
+		return "16BSI"
+	case Pixel16BUI:
+		return "16BUI"
+	case Pixel32BSI:
+		return "32BSI"
+	case Pixel32BUI:
+		return "32BUI"
+	case Pixel32BF:
+		return "32BF"
+	case Pixel64BF:
+		return "64BF"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Returns the number of bytes used for each pixel of the type in WKB.
+// Sub-byte types are stored one pixel per byte.
+func (p PixelType) ByteLength() int {
+	switch p {
+	case Pixel1BB, Pixel2BUI, Pixel4BUI, Pixel8BSI, Pixel8BUI:
+		return 1
+	case Pixel16BSI, Pixel16BUI:
+		return 2
+	case Pixel32BSI, Pixel32BUI, Pixel32BF:
+		return 4
+	case Pixel64BF:
+		return 8
+	}
+	// Unknown pixel type
+	return 0
+}
+
+// Read a single pixel value of the type from the start of the byte slice
+func (p PixelType) read(b []byte, order binary.ByteOrder) float64 {
+	switch p {
+	case Pixel1BB, Pixel2BUI, Pixel4BUI, Pixel8BUI:
+		return float64(b[0])
+	case Pixel8BSI:
+		return float64(int8(b[0]))
+	case Pixel16BSI:
+		return float64(int16(order.Uint16(b)))
+	case Pixel16BUI:
+		return float64(order.Uint16(b))
+	case Pixel32BSI:
+		return float64(int32(order.Uint32(b)))
+	case Pixel32BUI:
+		return float64(order.Uint32(b))
+	case Pixel32BF:
+		return float64(math.Float32frombits(order.Uint32(b)))
+	case Pixel64BF:
+		return math.Float64frombits(order.Uint64(b))
+	}
+	return math.NaN()
+}
+
+// Band flags, stored in the high 4 bits alongside the pixel type
+const (
+	bandIsOffline     byte = 0x80 // Band data is stored outside the database
+	bandHasNoData     byte = 0x40 // Band has a nodata value
+	bandIsNoData      byte = 0x20 // Every pixel of the band is nodata
+	bandPixelTypeMask byte = 0x0F
+)
+
+/*
+A Band is a single layer of pixel values in a Raster. Pixel values are held in
+row major order, width * height values, converted to float64 from the band
+PixelType. Out-db bands reference a file outside the database and hold no
+pixel values.
+*/
+type Band struct {
+	PixelType PixelType
+	HasNoData bool
+	IsNoData  bool
+	NoData    float64
+
+	IsOffline bool
+	OutDBBand int8   // 0-based band number in the out-db file
+	OutDBPath string // Path to the out-db file
+	Values    []float64
+}
+
+// Create a Band by reading from the start of b in the given byte order.
+// Returns the band and the number of bytes read.
+func bandFromWKB(b []byte, order binary.ByteOrder, width uint16, height uint16) (*Band, int, error) {
+	if len(b) < 1 {
+		return nil, 0, fmt.Errorf("input for band is too short to contain flags")
+	}
+	flags := b[0]
+	band := Band{
+		PixelType: PixelType(flags & bandPixelTypeMask),
+		IsOffline: flags&bandIsOffline == bandIsOffline,
+		HasNoData: flags&bandHasNoData == bandHasNoData,
+		IsNoData:  flags&bandIsNoData == bandIsNoData,
+	}
+	size := band.PixelType.ByteLength()
+	if size == 0 {
+		return nil, 0, fmt.Errorf("unknown band pixel type: %v", byte(band.PixelType))
+	}
+	offset := 1
+
+	// Nodata value is always present, sized by the pixel type
+	if len(b)-offset < size {
+		return nil, 0, fmt.Errorf("input for band is too short (%v) to contain nodata value", len(b))
+	}
+	band.NoData = band.PixelType.read(b[offset:], order)
+	offset += size
+
+	if band.IsOffline {
+		if len(b)-offset < 1 {
+			return nil, 0, fmt.Errorf("input for out-db band is too short to contain band number")
+		}
+		band.OutDBBand = int8(b[offset])
+		offset++
+
+		// Path is a null terminated string
+		end := offset
+		for end < len(b) && b[end] != 0 {
+			end++
+		}
+		if end == len(b) {
+			return nil, 0, fmt.Errorf("out-db band path is not null terminated")
+		}
+		band.OutDBPath = string(b[offset:end])
+		return &band, end + 1, nil
+	}
+
+	count := int(width) * int(height)
+	if len(b)-offset < count*size {
+		return nil, 0, fmt.Errorf("input for band is too short (%v) for %v x %v %v pixels", len(b)-offset, width, height, band.PixelType)
+	}
+	band.Values = make([]float64, count)
+	for i := range band.Values {
+		band.Values[i] = band.PixelType.read(b[offset:], order)
+		offset += size
+	}
+	return &band, offset, nil
+}
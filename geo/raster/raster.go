@@ -0,0 +1,191 @@
+package raster
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+/*
+	https://postgis.net/docs/using_raster_dataman.html
+
+	https://github.com/postgis/postgis/blob/master/raster/doc/RFC2-WellKnownBinaryFormat
+
+A Raster is a grid of pixels with one or more Bands of values, geo-referenced by
+an affine transform from pixel (column, row) to world (x, y) coordinates:
+
+	x = IPX + column*ScaleX + row*SkewX
+	y = IPY + column*SkewY + row*ScaleY
+
+where (IPX, IPY) is the upper left corner of the upper left pixel.
+*/
+type Raster struct {
+	Version uint16
+	ScaleX  float64
+	ScaleY  float64
+	IPX     float64
+	IPY     float64
+	SkewX   float64
+	SkewY   float64
+	SRID    int32
+	Width   uint16
+	Height  uint16
+	Bands   []Band
+}
+
+// Byte order markers for raster WKB
+const (
+	xdr byte = 0 // big endian
+	ndr byte = 1 // little endian
+)
+
+// Byte length of the raster WKB header
+const headerLength = 61
+
+// Create a Raster from raster WKB (binary, not hex encoded)
+func RasterFromWKB(b []byte) (*Raster, error) {
+	if len(b) < headerLength {
+		return nil, fmt.Errorf("raster wkb must be at least %v bytes to contain header, %v provided", headerLength, len(b))
+	}
+
+	var order binary.ByteOrder
+	switch b[0] {
+	case ndr:
+		order = binary.LittleEndian
+	case xdr:
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("unknown raster byte order: %v", b[0])
+	}
+
+	r := Raster{}
+	r.Version = order.Uint16(b[1:])
+	if r.Version != 0 {
+		return nil, fmt.Errorf("unsupported raster wkb version: %v", r.Version)
+	}
+	bandCount := order.Uint16(b[3:])
+	r.ScaleX = math.Float64frombits(order.Uint64(b[5:]))
+	r.ScaleY = math.Float64frombits(order.Uint64(b[13:]))
+	r.IPX = math.Float64frombits(order.Uint64(b[21:]))
+	r.IPY = math.Float64frombits(order.Uint64(b[29:]))
+	r.SkewX = math.Float64frombits(order.Uint64(b[37:]))
+	r.SkewY = math.Float64frombits(order.Uint64(b[45:]))
+	r.SRID = int32(order.Uint32(b[53:]))
+	r.Width = order.Uint16(b[57:])
+	r.Height = order.Uint16(b[59:])
+
+	offset := headerLength
+	for i := 0; i < int(bandCount); i++ {
+		band, n, err := bandFromWKB(b[offset:], order, r.Width, r.Height)
+		if err != nil {
+			return nil, fmt.Errorf("band %v: %w", i+1, err)
+		}
+		r.Bands = append(r.Bands, *band)
+		offset += n
+	}
+
+	return &r, nil
+}
+
+// Used to map raster values into structs when read by the database driver
+func (r *Raster) Scan(value interface{}) error {
+
+	// Format from PostGIS is Hex encoded raster WKB
+	var hexwkb []byte
+	switch v := value.(type) {
+	case []byte:
+		hexwkb = v
+	case string:
+		hexwkb = []byte(v)
+	default:
+		return fmt.Errorf("scan expected []byte, got %T (%v)", value, value)
+	}
+
+	wkb := make([]byte, hex.DecodedLen(len(hexwkb)))
+	_, err := hex.Decode(wkb, hexwkb)
+	if err != nil {
+		return err
+	}
+
+	raster, err := RasterFromWKB(wkb)
+	if err != nil {
+		return err
+	}
+	*r = *raster
+	return nil
+}
+
+// Get the world coordinates of the upper left corner of the pixel at (column, row)
+func (r Raster) PixelToWorld(column int, row int) (x float64, y float64) {
+	x = r.IPX + float64(column)*r.ScaleX + float64(row)*r.SkewX
+	y = r.IPY + float64(column)*r.SkewY + float64(row)*r.ScaleY
+	return
+}
+
+// Get the (column, row) of the pixel containing world coordinates (x, y), using the
+// inverse of the raster affine transform. Pixels outside the raster are returned
+// with an error.
+func (r Raster) WorldToPixel(x float64, y float64) (column int, row int, err error) {
+	det := r.ScaleX*r.ScaleY - r.SkewX*r.SkewY
+	if det == 0 {
+		return 0, 0, fmt.Errorf("raster geo-reference is not invertible")
+	}
+	dx, dy := x-r.IPX, y-r.IPY
+	c := (r.ScaleY*dx - r.SkewX*dy) / det
+	w := (r.ScaleX*dy - r.SkewY*dx) / det
+
+	column, row = int(math.Floor(c)), int(math.Floor(w))
+	if column < 0 || row < 0 || column >= int(r.Width) || row >= int(r.Height) {
+		return column, row, fmt.Errorf("coordinate (%v %v) is outside the raster", x, y)
+	}
+	return column, row, nil
+}
+
+// Get the value of the pixel at (column, row) in band n. Bands are numbered from 1,
+// as in ST_Value. nodata is true if the pixel holds the band nodata value.
+func (r Raster) PixelValue(n int, column int, row int) (value float64, nodata bool, err error) {
+	if n < 1 || n > len(r.Bands) {
+		return 0, false, fmt.Errorf("raster has no band %v", n)
+	}
+	band := r.Bands[n-1]
+	if band.IsOffline {
+		return 0, false, fmt.Errorf("band %v is stored out-db at %v", n, band.OutDBPath)
+	}
+	if column < 0 || row < 0 || column >= int(r.Width) || row >= int(r.Height) {
+		return 0, false, fmt.Errorf("pixel (%v %v) is outside the raster", column, row)
+	}
+	if band.IsNoData {
+		return band.NoData, true, nil
+	}
+	value = band.Values[row*int(r.Width)+column]
+	return value, band.HasNoData && value == band.NoData, nil
+}
+
+// Get the value in band n of the pixel containing the Point, using its X and Y
+// coordinates. The Point must be in the SRID of the raster.
+func (r Raster) PointValue(n int, p geo.Point) (value float64, nodata bool, err error) {
+	if len(p.Coords) < 2 {
+		return 0, false, fmt.Errorf("point has no x y coordinates")
+	}
+	column, row, err := r.WorldToPixel(p.Coords[0], p.Coords[1])
+	if err != nil {
+		return 0, false, err
+	}
+	return r.PixelValue(n, column, row)
+}
+
+// Get the value in band n of the pixel containing a Point geometry, such as the
+// geometry of a Location. The SRID of the geometry must match the raster.
+func (r Raster) Sample(n int, g geo.GISGeometry) (value float64, nodata bool, err error) {
+	point, ok := g.Geometry.(*geo.Point)
+	if !ok {
+		return 0, false, fmt.Errorf("raster can only be sampled by point geometry, %v provided", g.GeoType)
+	}
+	if g.SRIDFlag && int32(g.SRID) != r.SRID {
+		return 0, false, fmt.Errorf("geometry srid %v does not match raster srid %v", g.SRID, r.SRID)
+	}
+	return r.PointValue(n, *point)
+}
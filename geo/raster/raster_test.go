@@ -0,0 +1,143 @@
+package raster_test
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+	"github.com/stephenirven/go-postgis/geo/raster"
+)
+
+func TestRaster(t *testing.T) {
+
+	for _, order := range []binary.AppendByteOrder{binary.LittleEndian, binary.BigEndian} {
+
+		wkb := makeTestRasterWKB(order)
+
+		r := raster.Raster{}
+		err := r.Scan([]byte(hex.EncodeToString(wkb)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if r.Width != 3 || r.Height != 2 || r.SRID != 27700 || len(r.Bands) != 2 {
+			t.Errorf("raster header %v x %v srid %v bands %v was not 3 x 2 srid 27700 bands 2", r.Width, r.Height, r.SRID, len(r.Bands))
+		}
+		if r.Bands[0].PixelType != raster.Pixel16BSI {
+			t.Errorf("band pixel type %v was not %v", r.Bands[0].PixelType, raster.Pixel16BSI)
+		}
+
+		// Pixel (2, 1) covers x 120-130, y 180-190
+		point, err := geo.NewPoint([]float64{125, 185}, geo.XY)
+		if err != nil {
+			t.Error(err)
+		}
+		value, nodata, err := r.PointValue(1, *point)
+		if err != nil {
+			t.Error(err)
+		}
+		if value != -6 || nodata {
+			t.Errorf("pixel value %v (nodata %v) was not -6", value, nodata)
+		}
+
+		gisGeometry := geo.NewGISGeometry(point)
+		gisGeometry.SetSRID(27700)
+		value, _, err = r.Sample(2, gisGeometry)
+		if err != nil {
+			t.Error(err)
+		}
+		if value != 1.5 {
+			t.Errorf("pixel value %v was not 1.5", value)
+		}
+
+		// Pixel (1, 0) holds the nodata value
+		_, nodata, err = r.PixelValue(1, 1, 0)
+		if err != nil {
+			t.Error(err)
+		}
+		if !nodata {
+			t.Error("pixel holding the nodata value was not reported as nodata")
+		}
+
+		outside, err := geo.NewPoint([]float64{95, 185}, geo.XY)
+		if err != nil {
+			t.Error(err)
+		}
+		_, _, err = r.PointValue(1, *outside)
+		if err == nil {
+			t.Error("point outside the raster did not return an error")
+		}
+
+		gisGeometry.SetSRID(4326)
+		_, _, err = r.Sample(1, gisGeometry)
+		if err == nil {
+			t.Error("point in a different srid did not return an error")
+		}
+
+		_, err = raster.RasterFromWKB(wkb[:len(wkb)-1])
+		if err == nil {
+			t.Error("truncated raster did not return an error")
+		}
+	}
+}
+
+func TestRasterOutDB(t *testing.T) {
+
+	wkb := makeTestRasterHeader(binary.LittleEndian, 1)
+	wkb = append(wkb, 0x80|0x40|byte(raster.Pixel8BUI), 0, 2)
+	wkb = append(wkb, []byte("/data/dem.tif")...)
+	wkb = append(wkb, 0)
+
+	r, err := raster.RasterFromWKB(wkb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	band := r.Bands[0]
+	if !band.IsOffline || band.OutDBBand != 2 || band.OutDBPath != "/data/dem.tif" {
+		t.Errorf("out-db band %v was not band 2 of /data/dem.tif", band)
+	}
+
+	_, _, err = r.PixelValue(1, 0, 0)
+	if err == nil {
+		t.Error("out-db band value did not return an error")
+	}
+}
+
+// Make a 3 x 2 raster in SRID 27700 with 10 unit pixels, upper left at (100, 200),
+// a 16BSI band with nodata -9999 and a 64BF band.
+func makeTestRasterWKB(order binary.AppendByteOrder) []byte {
+
+	wkb := makeTestRasterHeader(order, 2)
+
+	wkb = append(wkb, 0x40|byte(raster.Pixel16BSI))
+	wkb = order.AppendUint16(wkb, uint16(0xffff&-9999))
+	for _, v := range []int16{1, -9999, 3, 4, 5, -6} {
+		wkb = order.AppendUint16(wkb, uint16(v))
+	}
+
+	wkb = append(wkb, byte(raster.Pixel64BF))
+	wkb = order.AppendUint64(wkb, 0)
+	for _, v := range []float64{0.5, 0.25, 0, 1, 2, 1.5} {
+		wkb = order.AppendUint64(wkb, math.Float64bits(v))
+	}
+	return wkb
+}
+
+func makeTestRasterHeader(order binary.AppendByteOrder, bands uint16) []byte {
+
+	wkb := []byte{0}
+	if order == binary.LittleEndian {
+		wkb[0] = 1
+	}
+	wkb = order.AppendUint16(wkb, 0) // version
+	wkb = order.AppendUint16(wkb, bands)
+	for _, v := range []float64{10, -10, 100, 200, 0, 0} { // scale, ip, skew
+		wkb = order.AppendUint64(wkb, math.Float64bits(v))
+	}
+	wkb = order.AppendUint32(wkb, 27700)
+	wkb = order.AppendUint16(wkb, 3)
+	wkb = order.AppendUint16(wkb, 2)
+	return wkb
+}
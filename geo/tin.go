@@ -37,11 +37,39 @@ func (t TIN) String() string {
 	return sb.String()
 }
 
+// Report whether the TIN equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (t TIN) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&t, other, o.Tolerance)
+	}
+	u, ok := subtypeAs[TIN](other)
+	if !ok || t.Dimensions != u.Dimensions || len(t.Triangles) != len(u.Triangles) {
+		return false
+	}
+	for i, triangle := range t.Triangles {
+		if !triangle.Equals(u.Triangles[i], o) {
+			return false
+		}
+	}
+	return true
+}
+
 // Get the dimensions of the geometry
 func (t TIN) GetDimensions() Dimensions {
 	return t.Dimensions
 }
 
+// Get the bounding box of the TIN in each of its dimensions
+func (t TIN) Envelope() Envelope {
+	e := NewEnvelope(t.Dimensions)
+	for _, triangle := range t.Triangles {
+		e.add(triangle.Envelope())
+	}
+	return e.clear()
+}
+
 // Create a TIN from a slice of Triangles of the same dimensions.
 // Length must be at least 1
 func NewTIN(t []Triangle) (*TIN, error) {
@@ -54,27 +82,41 @@ func NewTIN(t []Triangle) (*TIN, error) {
 	return &ti, nil
 }
 
-// Create a new TIN from input byte buffer in EWKB format and dimensions.
-func TINFromEWKB(b *bytes.Buffer, dimensions Dimensions) (*TIN, error) {
+// Create a new TIN from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func TINFromEWKB(b *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*TIN, error) {
+	d, err := newDecodeState(pathName(TINType), b.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return tinFromEWKB(b, dimensions, d)
+}
+
+func tinFromEWKB(b *bytes.Buffer, dimensions Dimensions, d *decodeState) (*TIN, error) {
 
 	tin := TIN{}
 	tin.Dimensions = dimensions
-	if b.Len() < 4 {
-		return nil, fmt.Errorf("byte array for tin must be at least length 4")
+	count, err := d.readCount(b, "tin")
+	if err != nil {
+		return nil, err
 	}
-
-	count := binary.LittleEndian.Uint32(b.Next(4))
+	if err := d.enter(b); err != nil {
+		return nil, err
+	}
+	defer d.leave()
 
 	for i := 0; i < int(count); i++ {
-		//bom := data[0]
-		b.Next(1)
-		// geometryType := data[1:5]
-		b.Next(4)
+		d.at(i)
+		_, memberDimensions, err := d.readMemberType(b, "tin", dimensions, TriangleType)
+		if err != nil {
+			return nil, err
+		}
 
-		triangle, err := TriangleFromEWKB(b, dimensions)
+		triangle, err := triangleFromEWKB(b, memberDimensions, d)
 		if err != nil {
-			return nil, fmt.Errorf(err.Error())
+			return nil, err
 		}
+		forceDimensions(triangle, dimensions)
 
 		tin.Triangles = append(tin.Triangles, *triangle)
 	}
@@ -106,3 +148,72 @@ func (t TIN) GetEWKB(includeGeoType bool) bytes.Buffer {
 	}
 	return *buf
 }
+
+// Report whether the TIN is valid, as ST_IsValid
+func (t TIN) IsValid() bool {
+	return t.Validate() == nil
+}
+
+// Check the Triangles of the TIN are valid
+func (t TIN) Validate() error {
+	return validityError(t.validate(), pathName(TINType))
+}
+
+func (t TIN) validate() *ValidityError {
+	for i, triangle := range t.Triangles {
+		e := checkDimensions(triangle.Dimensions, t.Dimensions, nil)
+		if e == nil {
+			e = triangle.validate()
+		}
+		if e != nil {
+			return e.at(i)
+		}
+	}
+	return nil
+}
+
+// Get the area of the TIN, as ST_Area
+func (t TIN) Area() float64 {
+	total := 0.0
+	for _, triangle := range t.Triangles {
+		total += triangle.Area()
+	}
+	return total
+}
+
+// Get the length of the TIN, which has none
+func (t TIN) Length() float64 {
+	return 0
+}
+
+// Get the perimeter of the TIN, as ST_Perimeter
+func (t TIN) Perimeter() float64 {
+	total := 0.0
+	for _, triangle := range t.Triangles {
+		total += triangle.Perimeter()
+	}
+	return total
+}
+
+// Get the area of the TIN using Z, as ST_3DArea
+func (t TIN) Area3D() float64 {
+	total := 0.0
+	for _, triangle := range t.Triangles {
+		total += triangle.Area3D()
+	}
+	return total
+}
+
+// Get the perimeter of the TIN using Z, as ST_3DPerimeter
+func (t TIN) Perimeter3D() float64 {
+	total := 0.0
+	for _, triangle := range t.Triangles {
+		total += triangle.Perimeter3D()
+	}
+	return total
+}
+
+// Get the minimum distance between the TIN and another geometry, as ST_Distance
+func (t TIN) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&t, other)
+}
@@ -5,8 +5,6 @@ import (
 	"encoding/binary"
 	"fmt"
 	"strings"
-
-	"github.com/google/go-cmp/cmp"
 )
 
 /*
@@ -17,7 +15,7 @@ Because a Triangle is a polygon it is specified by four coordinates, with
 the first and fourth being equal.
 */
 type Triangle struct {
-	Points     [4]Point
+	Coords     []float64
 	Dimensions Dimensions
 }
 
@@ -31,60 +29,130 @@ func (t Triangle) String() string {
 	sb.WriteString("(Triangle ")
 	sb.WriteString(t.Dimensions.String())
 	sb.WriteString(" [")
-	for _, p := range t.Points {
-		sb.WriteString(p.String())
-		sb.WriteString(" ")
-	}
+	writeCoordsString(&sb, t.Coords, t.Dimensions)
 
 	sb.WriteString("])")
 	return sb.String()
 }
 
+// Report whether the Triangle equals another geometry, as ST_OrderingEquals or as
+// set by the EqualOptions
+func (t Triangle) Equals(other GeometrySubtype, opts ...EqualOptions) bool {
+	o := equalOptions(opts)
+	if o.Topological {
+		return topologicallyEqual(&t, other, o.Tolerance)
+	}
+	u, ok := subtypeAs[Triangle](other)
+	return ok && t.Dimensions == u.Dimensions && sequencesEqual(t.Coords, u.Coords, t.Dimensions, 1, o)
+}
+
 // Get the dimensions of the geometry
 func (t Triangle) GetDimensions() Dimensions {
 	return t.Dimensions
 }
 
+// Get the bounding box of the Triangle in each of its dimensions
+func (t Triangle) Envelope() Envelope {
+	return coordsEnvelope(t.Coords, t.Dimensions)
+}
+
+// Get the number of points in the Triangle
+func (t Triangle) NumPoints() int {
+	return coordCount(t.Coords, t.Dimensions)
+}
+
+// Get the ordinates of the point at index i. The slice shares storage with the Triangle.
+func (t Triangle) Coord(i int) []float64 {
+	return coordAt(t.Coords, t.Dimensions, i)
+}
+
+// Get the point at index i. The point shares storage with the Triangle.
+func (t Triangle) PointN(i int) Point {
+	return Point{Coords: t.Coord(i), Dimensions: t.Dimensions}
+}
+
+// Get the four points of the Triangle, the last repeating the first, each sharing storage with it
+func (t Triangle) Points() [4]Point {
+	var p [4]Point
+	copy(p[:], pointsFromCoords(t.Coords, t.Dimensions))
+	return p
+}
+
 // Create a Triangle from an array of Points, length 4 of the same dimensions.
 // First and last Points must be equal.
 func NewTriangle(p [4]Point) (*Triangle, error) {
-	if !cmp.Equal(p[0], p[3]) {
-		return nil, fmt.Errorf("first and last point of triangle must be equal")
+	coords, err := flattenPoints(p[:], p[0].GetDimensions())
+	if err != nil {
+		return nil, fmt.Errorf("error creating triangle, %v", err)
 	}
+	return TriangleFromCoords(coords, p[0].GetDimensions())
+}
 
-	return &Triangle{
-		Dimensions: p[0].GetDimensions(),
-		Points:     p,
-	}, nil
+// Create a Triangle from a flat slice of ordinates for 4 points and Dimensions.
+// First and last Points must be equal.
+func TriangleFromCoords(coords []float64, dimensions Dimensions) (*Triangle, error) {
+	if err := checkCoords(coords, dimensions); err != nil {
+		return nil, fmt.Errorf("error creating triangle, %v", err)
+	}
+	t := Triangle{Coords: coords, Dimensions: dimensions}
+	if t.NumPoints() != 4 {
+		return nil, fmt.Errorf("triangle must contain 4 points (first & last must be the same)")
+	}
+	if !coordsEqual(t.Coord(0), t.Coord(3)) {
+		return nil, fmt.Errorf("first and last point of triangle must be equal")
+	}
+	return &t, nil
+}
 
+// Create a new Triangle from input byte buffer in EWKB format and dimensions,
+// within the limits of the DecodeOptions if given.
+func TriangleFromEWKB(b *bytes.Buffer, dimensions Dimensions, opts ...DecodeOptions) (*Triangle, error) {
+	d, err := newDecodeState(pathName(TriangleType), b.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return triangleFromEWKB(b, dimensions, d)
 }
 
-// Create a new Triangle from input byte buffer in EWKB format and dimensions.
-func TriangleFromEWKB(b *bytes.Buffer, dimensions Dimensions) (*Triangle, error) {
+func triangleFromEWKB(b *bytes.Buffer, dimensions Dimensions, d *decodeState) (*Triangle, error) {
 
 	t := Triangle{}
 	t.Dimensions = dimensions
-	if b.Len() < 4 {
-		return nil, fmt.Errorf("byte array for triangle must be at least length 4")
+	// Triangles are encoded as a polygon of one ring, or none if empty
+	ringCount, err := d.readCount(b, "triangle")
+	if err != nil {
+		return nil, err
+	}
+	if ringCount == 0 {
+		return &t, nil
+	}
+	if ringCount != 1 {
+		return nil, d.errorAt(d.offset(b)-4, fmt.Errorf("triangle must contain one ring, %v provided", ringCount))
 	}
 
-	b.Next(4) // Move past geotype?
-
-	pointCount := binary.LittleEndian.Uint32(b.Next(4))
+	pointCount, err := d.readCount(b, "triangle ring")
+	if err != nil {
+		return nil, err
+	}
 
-	if pointCount != 4 {
-		return nil, fmt.Errorf("triangle must contain 4 points (first & last must be the same)")
+	// Lenient decoding closes triangles of 3 points
+	if pointCount != 4 && (pointCount != 3 || d.opts.Mode != DecodeLenient) {
+		return nil, d.errorAt(d.offset(b)-4, fmt.Errorf("triangle must contain 4 points (first & last must be the same)"))
 	}
 
-	for i := 0; i < 4; i++ {
-		point, err := PointFromEWKB(b, dimensions)
-		if err != nil {
-			return nil, fmt.Errorf(err.Error())
-		}
-		t.Points[i] = *point
+	start := d.offset(b)
+	coords, err := d.readCoords(b, dimensions, pointCount)
+	if err != nil {
+		return nil, err
 	}
-	if !cmp.Equal(t.Points[0], t.Points[3]) {
-		return nil, fmt.Errorf("first and last point of triangle must be equal")
+	t.Coords = coords
+	if pointCount == 3 {
+		d.warn(start, "closed triangle by repeating its first point")
+		t.Coords = append(t.Coords, t.Coord(0)...)
+	}
+
+	if !coordsEqual(t.Coord(0), t.Coord(3)) {
+		return nil, d.errorAt(start, fmt.Errorf("first and last point of triangle must be equal"))
 	}
 
 	return &t, nil
@@ -101,16 +169,83 @@ func (t Triangle) GetEWKB(includeGeoType bool) bytes.Buffer {
 		buf.Write(geoTypeBytes)
 	}
 
+	if t.NumPoints() == 0 { // empty triangles have no ring
+		buf.Write(binary.LittleEndian.AppendUint32([]byte{}, uint32(0)))
+		return *buf
+	}
+
 	ringLengthBytes := binary.LittleEndian.AppendUint32([]byte{}, uint32(1))
 	buf.Write(ringLengthBytes)
 
-	pointLengthBytes := binary.LittleEndian.AppendUint32([]byte{}, uint32(len(t.Points)))
+	pointLengthBytes := binary.LittleEndian.AppendUint32([]byte{}, uint32(t.NumPoints()))
 	buf.Write(pointLengthBytes)
 
-	for _, p := range t.Points {
-		// no geotype stuff
-		pb := p.GetEWKB(false)
-		pb.WriteTo(buf)
-	}
+	// no geotype stuff
+	writeCoords(buf, t.Coords)
 	return *buf
 }
+
+// Report whether the Triangle is valid, as ST_IsValid
+func (t Triangle) IsValid() bool {
+	return t.Validate() == nil
+}
+
+// Check the Triangle is empty, or has 4 points with the first and last equal
+// and the others not collinear
+func (t Triangle) Validate() error {
+	return validityError(t.validate(), pathName(TriangleType))
+}
+
+func (t Triangle) validate() *ValidityError {
+	if t.NumPoints() == 0 {
+		return nil
+	}
+	if e := validateRingStructure(t.Coords, t.Dimensions); e != nil {
+		return e
+	}
+	if t.NumPoints() != 4 {
+		return invalid("Triangle must have 4 points", t.Coord(0))
+	}
+
+	// The cross product of two sides, which is zero if the corners are collinear
+	a, b, c := t.Coord(0), t.Coord(1), t.Coord(2)
+	u := [3]float64{b[0] - a[0], b[1] - a[1]}
+	v := [3]float64{c[0] - a[0], c[1] - a[1]}
+	if t.Dimensions.HasZ() {
+		u[2], v[2] = b[2]-a[2], c[2]-a[2]
+	}
+	if u[1]*v[2]-u[2]*v[1] == 0 && u[2]*v[0]-u[0]*v[2] == 0 && u[0]*v[1]-u[1]*v[0] == 0 {
+		return invalid("Triangle is degenerate", a)
+	}
+	return nil
+}
+
+// Get the area of the Triangle, as ST_Area
+func (t Triangle) Area() float64 {
+	return ringArea(t.Coords, t.Dimensions)
+}
+
+// Get the length of the Triangle, which has none
+func (t Triangle) Length() float64 {
+	return 0
+}
+
+// Get the perimeter of the Triangle, as ST_Perimeter
+func (t Triangle) Perimeter() float64 {
+	return polylineLength(t.Coords, t.Dimensions)
+}
+
+// Get the area of the Triangle using Z, as ST_3DArea
+func (t Triangle) Area3D() float64 {
+	return ringArea3D(t.Coords, t.Dimensions)
+}
+
+// Get the perimeter of the Triangle using Z, as ST_3DPerimeter
+func (t Triangle) Perimeter3D() float64 {
+	return polylineLength3D(t.Coords, t.Dimensions)
+}
+
+// Get the minimum distance between the Triangle and another geometry, as ST_Distance
+func (t Triangle) Distance(other GeometrySubtype) (float64, error) {
+	return distance(&t, other)
+}
@@ -0,0 +1,427 @@
+package geo
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+	https://postgis.net/docs/ST_IsValid.html
+
+Validity follows the OGC rules PostGIS checks with ST_IsValid, evaluated in the
+XY plane:
+
+  - point sequences have finite ordinates, linestrings at least 2 distinct
+    points and circularstrings an odd number of points of at least 3
+  - rings are closed, have at least 4 points and do not intersect themselves
+  - the rings of a polygon do not cross or overlap, holes lie within the shell
+    and are not nested within each other
+  - the polygons of a multipolygon do not cross, overlap or nest
+  - triangles have 4 points, the first and last equal, and are not collinear
+  - the components of a compoundcurve are continuous, and the rings of a
+    curvepolygon are closed
+  - members of a multi geometry have the dimensions of their parent
+
+The faces of polyhedral surfaces and TINs are in 3D, so only their structure
+is checked. Rings may touch at single points, and a polygon whose interior
+is disconnected by such touches is not detected.
+*/
+
+// ValidityError describes why a geometry is not valid, as ST_IsValidReason
+type ValidityError struct {
+	Reason   string    // such as "Self-intersection"
+	Location []float64 // X and Y of the offending point, if any
+	Path     string    // path to the offending element, such as MultiPolygon[3].ring[1]
+}
+
+func (e *ValidityError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Reason)
+	if len(e.Location) >= 2 {
+		sb.WriteString("[" + strconv.FormatFloat(e.Location[0], 'f', -1, 64) + " " + strconv.FormatFloat(e.Location[1], 'f', -1, 64) + "]")
+	}
+	if e.Path != "" {
+		sb.WriteString(" in " + e.Path)
+	}
+	return sb.String()
+}
+
+// Create a ValidityError at a point, if given
+func invalid(reason string, p []float64) *ValidityError {
+	e := &ValidityError{Reason: reason}
+	if len(p) >= 2 {
+		e.Location = []float64{p[0], p[1]}
+	}
+	return e
+}
+
+// Add an element to the start of the path of the error. Elements starting
+// with an index are joined to the following element without a separator,
+// such as "[2]" and "ring[1]" giving "[2].ring[1]".
+func (e *ValidityError) in(element string) *ValidityError {
+	switch {
+	case e.Path == "":
+		e.Path = element
+	case strings.HasPrefix(e.Path, "["):
+		e.Path = element + e.Path
+	default:
+		e.Path = element + "." + e.Path
+	}
+	return e
+}
+
+// Add an index to the start of the path of the error
+func (e *ValidityError) at(i int) *ValidityError {
+	return e.in("[" + strconv.Itoa(i) + "]")
+}
+
+// Convert a ValidityError to an error, without a nil *ValidityError becoming
+// a non-nil error. The name of the geometry starts the path.
+func validityError(e *ValidityError, name string) error {
+	if e == nil {
+		return nil
+	}
+	return e.in(name)
+}
+
+// Check the members of a multi geometry are of the allowed types
+func validateMemberTypes(members []GeometrySubtype, name string, allowed ...GISGeometryType) *ValidityError {
+	for i, member := range members {
+		if t := member.GetGISGeometryType(); !containsType(allowed, t) {
+			return invalid(name+" must not contain "+pathName(t), nil).at(i)
+		}
+	}
+	return nil
+}
+
+// Check the members of a multi geometry are valid and have its dimensions
+func validateMembers(members []GeometrySubtype, dimensions Dimensions) *ValidityError {
+	for i, member := range members {
+		if e := checkDimensions(member.GetDimensions(), dimensions, nil); e != nil {
+			return e.at(i)
+		}
+		if err := member.Validate(); err != nil {
+			e, ok := err.(*ValidityError)
+			if !ok {
+				e = invalid(err.Error(), nil)
+			}
+			return e.at(i)
+		}
+	}
+	return nil
+}
+
+// Check a member has the dimensions of its parent
+func checkDimensions(member Dimensions, parent Dimensions, p []float64) *ValidityError {
+	if member != parent {
+		return invalid("Mixed dimensions "+member.String()+" in "+parent.String(), p)
+	}
+	return nil
+}
+
+// Check a flat coordinate slice holds whole points of finite ordinates. Empty
+// points, of all NaN ordinates, are allowed if emptyPoints is set.
+func validateCoords(coords []float64, dimensions Dimensions, emptyPoints bool) *ValidityError {
+	if err := checkCoords(coords, dimensions); err != nil {
+		return invalid("Invalid Coordinate: "+err.Error(), nil)
+	}
+	for i := 0; i < coordCount(coords, dimensions); i++ {
+		p := coordAt(coords, dimensions, i)
+		if emptyPoints && pointEmpty(p) {
+			continue
+		}
+		for _, c := range p {
+			if math.IsNaN(c) || math.IsInf(c, 0) {
+				return invalid("Invalid Coordinate", p).in("point[" + strconv.Itoa(i) + "]")
+			}
+		}
+	}
+	return nil
+}
+
+// Get the XY coordinates of a point sequence, without consecutive repeated points
+func distinctXY(coords []float64, dimensions Dimensions) [][2]float64 {
+	var xy [][2]float64
+	for i := 0; i < coordCount(coords, dimensions); i++ {
+		p := coordAt(coords, dimensions, i)
+		q := [2]float64{p[0], p[1]}
+		if len(xy) == 0 || xy[len(xy)-1] != q {
+			xy = append(xy, q)
+		}
+	}
+	return xy
+}
+
+// Check the structure of a ring: finite, closed and of at least 4 points
+func validateRingStructure(coords []float64, dimensions Dimensions) *ValidityError {
+	if e := validateCoords(coords, dimensions, false); e != nil {
+		return e
+	}
+	n := coordCount(coords, dimensions)
+	if n == 0 {
+		return invalid("Too few points in geometry component", nil)
+	}
+	if !coordsEqual(coordAt(coords, dimensions, 0), coordAt(coords, dimensions, n-1)) {
+		return invalid("Ring is not closed", coordAt(coords, dimensions, 0))
+	}
+	if n < 4 {
+		return invalid("Too few points in geometry component", coordAt(coords, dimensions, 0))
+	}
+	return nil
+}
+
+// Check a ring is structurally valid, has at least 3 distinct points and
+// does not intersect itself
+func validateRing(coords []float64, dimensions Dimensions) *ValidityError {
+	if e := validateRingStructure(coords, dimensions); e != nil {
+		return e
+	}
+	ring := distinctXY(coords, dimensions)
+	if len(ring) < 4 {
+		return invalid("Too few points in geometry component", ring[0][:])
+	}
+
+	segments := len(ring) - 1
+	for i := 0; i < segments; i++ {
+		for j := i + 1; j < segments; j++ {
+			kind, p := intersectSegments(ring[i], ring[i+1], ring[j], ring[j+1])
+			adjacent := j == i+1 || (i == 0 && j == segments-1)
+			if kind == crossing || kind == overlapping || (kind == touching && !adjacent) {
+				return invalid("Ring Self-intersection", p[:])
+			}
+		}
+	}
+	return nil
+}
+
+// Check no segment of one ring crosses or overlaps a segment of another
+func validateRingCrossings(a [][2]float64, b [][2]float64) *ValidityError {
+	for i := 1; i < len(a); i++ {
+		for j := 1; j < len(b); j++ {
+			if kind, p := intersectSegments(a[i-1], a[i], b[j-1], b[j]); kind == crossing || kind == overlapping {
+				return invalid("Self-intersection", p[:])
+			}
+		}
+	}
+	return nil
+}
+
+// Get a vertex of the ring that is not on the boundary of the other ring, or
+// the midpoint of a segment if every vertex is
+func testPoint(ring [][2]float64, other [][2]float64) [2]float64 {
+	for _, p := range ring {
+		if locateInRing(p, other) != onBoundary {
+			return p
+		}
+	}
+	return [2]float64{(ring[0][0] + ring[1][0]) / 2, (ring[0][1] + ring[1][1]) / 2}
+}
+
+// Kinds of intersection between two segments
+type intersection int
+
+const (
+	disjoint    intersection = iota
+	touching                 // at a single point that is an end of either segment
+	crossing                 // at a single point interior to both segments
+	overlapping              // along a collinear stretch
+)
+
+// Bound on the relative rounding error of a cross product
+const crossErrorBound = (3 + 16*epsilon) * epsilon
+
+// Difference between 1 and the next float64 below it, halved
+const epsilon = 1.0 / (1 << 53)
+
+// Get the cross product of (b - a) and (c - a), with the sign of the exact
+// result so nearly collinear points are not misjudged
+func cross(a, b, c [2]float64) float64 {
+	left := (b[0] - a[0]) * (c[1] - a[1])
+	right := (b[1] - a[1]) * (c[0] - a[0])
+	det := left - right
+	if math.Abs(det) > crossErrorBound*(math.Abs(left)+math.Abs(right)) {
+		return det
+	}
+
+	// Too close to call with rounding, so sum the exact differences and
+	// products, each the sum of a rounded value and its rounding error
+	dx1, ex1 := twoSum(b[0], -a[0])
+	dy1, ey1 := twoSum(b[1], -a[1])
+	dx2, ex2 := twoSum(c[0], -a[0])
+	dy2, ey2 := twoSum(c[1], -a[1])
+	var terms [16]float64
+	sum := terms[:0]
+	for _, factors := range [2][2][2]float64{{{dx1, ex1}, {dy2, ey2}}, {{-dy1, -ey1}, {dx2, ex2}}} {
+		for _, u := range factors[0] {
+			for _, v := range factors[1] {
+				product := u * v
+				sum = growExpansion(sum, product)
+				sum = growExpansion(sum, math.FMA(u, v, -product))
+			}
+		}
+	}
+	det = 0
+	for _, t := range sum {
+		det += t
+	}
+	return det
+}
+
+// Get the rounded sum of two numbers and its rounding error
+func twoSum(a, b float64) (float64, float64) {
+	s := a + b
+	bv := s - a
+	av := s - bv
+	return s, (a - av) + (b - bv)
+}
+
+// Add a number to an expansion, a sum of non-overlapping numbers in increasing
+// magnitude, keeping it exact
+func growExpansion(e []float64, b float64) []float64 {
+	q := b
+	for i, t := range e {
+		q, e[i] = twoSum(q, t)
+	}
+	return append(e, q)
+}
+
+// Report whether the point lies on the segment
+func onSegment(p, a, b [2]float64) bool {
+	return cross(a, b, p) == 0 &&
+		math.Min(a[0], b[0]) <= p[0] && p[0] <= math.Max(a[0], b[0]) &&
+		math.Min(a[1], b[1]) <= p[1] && p[1] <= math.Max(a[1], b[1])
+}
+
+// Get how segments a-b and c-d intersect, and a point of the intersection
+func intersectSegments(a, b, c, d [2]float64) (intersection, [2]float64) {
+	d1, d2 := cross(c, d, a), cross(c, d, b)
+	d3, d4 := cross(a, b, c), cross(a, b, d)
+
+	if d1 == 0 && d2 == 0 && d3 == 0 && d4 == 0 {
+		// Collinear, so compare positions along the longer axis of a-b
+		axis := 0
+		if math.Abs(b[1]-a[1]) > math.Abs(b[0]-a[0]) {
+			axis = 1
+		}
+		lo := math.Max(math.Min(a[axis], b[axis]), math.Min(c[axis], d[axis]))
+		hi := math.Min(math.Max(a[axis], b[axis]), math.Max(c[axis], d[axis]))
+		switch {
+		case lo > hi:
+			return disjoint, [2]float64{}
+		case lo == hi:
+			for _, p := range [][2]float64{c, d, a, b} {
+				if p[axis] == lo {
+					return touching, p
+				}
+			}
+		}
+		for _, p := range [][2]float64{c, d, a, b} {
+			if p[axis] == lo {
+				return overlapping, p
+			}
+		}
+	}
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		t := d1 / (d1 - d2)
+		return crossing, [2]float64{a[0] + t*(b[0]-a[0]), a[1] + t*(b[1]-a[1])}
+	}
+	for _, p := range [][2]float64{a, b} {
+		if onSegment(p, c, d) {
+			return touching, p
+		}
+	}
+	for _, p := range [][2]float64{c, d} {
+		if onSegment(p, a, b) {
+			return touching, p
+		}
+	}
+	return disjoint, [2]float64{}
+}
+
+// Location of a point relative to a ring
+type ringLocation int
+
+const (
+	outside ringLocation = iota
+	onBoundary
+	inside
+)
+
+// Locate a point relative to a closed ring, by ray casting
+func locateInRing(p [2]float64, ring [][2]float64) ringLocation {
+	in := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if onSegment(p, a, b) {
+			return onBoundary
+		}
+		if (a[1] > p[1]) != (b[1] > p[1]) && p[0] < (b[0]-a[0])*(p[1]-a[1])/(b[1]-a[1])+a[0] {
+			in = !in
+		}
+	}
+	if in {
+		return inside
+	}
+	return outside
+}
+
+// Get the first and last points of a curve, which is empty if it has none
+func curveEnds(g GeometrySubtype) (first []float64, last []float64, empty bool) {
+	switch t := g.(type) {
+	case *LineString:
+		if n := t.NumPoints(); n > 0 {
+			return t.Coord(0), t.Coord(n - 1), false
+		}
+	case *CircularString:
+		if n := t.NumPoints(); n > 0 {
+			return t.Coord(0), t.Coord(n - 1), false
+		}
+	case *CompoundCurve:
+		for _, component := range t.Geometry {
+			if first, _, empty := curveEnds(component); !empty {
+				return first, lastEnd(t.Geometry), false
+			}
+		}
+	}
+	return nil, nil, true
+}
+
+// Get the last point of the last non-empty curve
+func lastEnd(curves []GeometrySubtype) []float64 {
+	for i := len(curves) - 1; i >= 0; i-- {
+		if _, last, empty := curveEnds(curves[i]); !empty {
+			return last
+		}
+	}
+	return nil
+}
+
+// Report whether the geometry is valid, as ST_IsValid
+func (g GISGeometry) IsValid() bool {
+	return g.Validate() == nil
+}
+
+// Check the geometry is valid, returning a *ValidityError giving the reason
+// if it is not
+func (g GISGeometry) Validate() error {
+	if g.Geometry == nil {
+		return invalid("Geometry is missing", nil)
+	}
+	if g.Geometry.GetGISGeometryType() != g.GeoType {
+		return invalid("Geometry type "+g.GeoType.String()+" does not match "+g.Geometry.GetGISGeometryType().String(), nil)
+	}
+	if e := checkDimensions(g.Geometry.GetDimensions(), g.Dimensions, nil); e != nil {
+		return e
+	}
+	return g.Geometry.Validate()
+}
+
+// Get the reason the geometry is not valid, or "Valid Geometry", as ST_IsValidReason
+func (g GISGeometry) ValidReason() string {
+	if err := g.Validate(); err != nil {
+		return err.Error()
+	}
+	return "Valid Geometry"
+}
@@ -0,0 +1,127 @@
+package geo_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stephenirven/go-postgis/geo"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry geo.GeometrySubtype
+		reason   string // empty if valid
+		location []float64
+		path     string
+	}{
+		{"point", &geo.Point{Coords: []float64{1, 2}, Dimensions: geo.XY}, "", nil, ""},
+		{"empty point", &geo.Point{Coords: []float64{math.NaN(), math.NaN()}, Dimensions: geo.XY}, "", nil, ""},
+		{"infinite point", &geo.Point{Coords: []float64{math.Inf(1), 2}, Dimensions: geo.XY},
+			"Invalid Coordinate", []float64{math.Inf(1), 2}, "Point"},
+		{"linestring", xyLineString(0, 0, 1, 1), "", nil, ""},
+		{"linestring of repeated point", xyLineString(1, 1, 1, 1),
+			"Too few points in geometry component", []float64{1, 1}, "LineString"},
+		{"polygon", xyPolygon(xySquare(0, 0, 10), xySquare(2, 2, 2)), "", nil, ""},
+		{"unclosed ring", xyPolygon(xyRing(0, 0, 1, 0, 1, 1, 0, 1)),
+			"Ring is not closed", []float64{0, 0}, "Polygon.ring[0]"},
+		{"ring of 3 points", xyPolygon(xyRing(0, 0, 1, 1, 0, 0)),
+			"Too few points in geometry component", []float64{0, 0}, "Polygon.ring[0]"},
+		{"bow-tie", xyPolygon(xyRing(0, 0, 2, 2, 2, 0, 0, 2, 0, 0)),
+			"Ring Self-intersection", []float64{1, 1}, "Polygon.ring[0]"},
+		{"hole outside shell", xyPolygon(xySquare(0, 0, 10), xySquare(20, 20, 2)),
+			"Hole lies outside shell", []float64{20, 20}, "Polygon.ring[1]"},
+		{"hole crossing shell", xyPolygon(xySquare(0, 0, 10), xySquare(8, 2, 4)),
+			"Self-intersection", []float64{10, 2}, "Polygon.ring[1]"},
+		{"hole touching shell", xyPolygon(xySquare(0, 0, 10), xyRing(0, 5, 2, 4, 2, 6, 0, 5)), "", nil, ""},
+		{"nested holes", xyPolygon(xySquare(0, 0, 10), xySquare(1, 1, 8), xySquare(2, 2, 2)),
+			"Holes are nested", []float64{2, 2}, "Polygon.ring[2]"},
+		{"adjacent polygons", &geo.MultiPolygon{Polygons: []geo.Polygon{*xyPolygon(xySquare(0, 0, 1)), *xyPolygon(xySquare(1, 0, 1))}, Dimensions: geo.XY},
+			"Self-intersection", []float64{1, 0}, "MultiPolygon[1]"},
+		{"nested shells", &geo.MultiPolygon{Polygons: []geo.Polygon{*xyPolygon(xySquare(0, 0, 10)), *xyPolygon(xySquare(2, 2, 2))}, Dimensions: geo.XY},
+			"Nested shells", []float64{2, 2}, "MultiPolygon[1]"},
+		{"shell in hole", &geo.MultiPolygon{Polygons: []geo.Polygon{*xyPolygon(xySquare(0, 0, 10), xySquare(1, 1, 8)), *xyPolygon(xySquare(2, 2, 2))}, Dimensions: geo.XY},
+			"", nil, ""},
+		{"mixed dimensions", &geo.MultiLineString{LineStrings: []geo.LineString{{Coords: []float64{0, 0, 0, 1, 1, 1}, Dimensions: geo.XYZ}}, Dimensions: geo.XY},
+			"Mixed dimensions XYZ in XY", nil, "MultiLineString[0]"},
+		{"triangle", &geo.Triangle{Coords: []float64{0, 0, 1, 0, 0, 1, 0, 0}, Dimensions: geo.XY}, "", nil, ""},
+		{"collinear triangle", &geo.Triangle{Coords: []float64{0, 0, 1, 1, 2, 2, 0, 0}, Dimensions: geo.XY},
+			"Triangle is degenerate", []float64{0, 0}, "Triangle"},
+		{"vertical triangle", &geo.Triangle{Coords: []float64{0, 0, 0, 1, 1, 0, 0, 0, 1, 0, 0, 0}, Dimensions: geo.XYZ}, "", nil, ""},
+		{"even circularstring", xyCircularString(0, 0, 1, 1, 2, 0, 3, 1),
+			"CircularString must have an odd number of points", []float64{0, 0}, "CircularString"},
+		{"compoundcurve", &geo.CompoundCurve{Geometry: []geo.GeometrySubtype{xyCircularString(0, 0, 1, 1, 2, 0), xyLineString(2, 0, 4, 0)}, Dimensions: geo.XY},
+			"", nil, ""},
+		{"discontinuous compoundcurve", &geo.CompoundCurve{Geometry: []geo.GeometrySubtype{xyCircularString(0, 0, 1, 1, 2, 0), xyLineString(3, 0, 4, 0)}, Dimensions: geo.XY},
+			"CompoundCurve components are not continuous", []float64{3, 0}, "CompoundCurve[1]"},
+		{"curvepolygon", &geo.CurvePolygon{Geometry: []geo.GeometrySubtype{xyCircularString(0, 0, 2, 0, 0, 0)}, Dimensions: geo.XY}, "", nil, ""},
+		{"unclosed curvepolygon", &geo.CurvePolygon{Geometry: []geo.GeometrySubtype{xyCircularString(0, 0, 1, 1, 2, 0)}, Dimensions: geo.XY},
+			"Ring is not closed", []float64{0, 0}, "CurvePolygon[0]"},
+		{"collection", &geo.GeometryCollection{Geometry: []geo.GeometrySubtype{xyLineString(0, 0, 1, 1), xyPolygon(xySquare(0, 0, 10), xySquare(20, 20, 2))}, Dimensions: geo.XY},
+			"Hole lies outside shell", []float64{20, 20}, "GeometryCollection[1].Polygon.ring[1]"},
+		{"multicurve member", &geo.MultiCurve{Geometry: []geo.GeometrySubtype{xyPolygon(xySquare(0, 0, 1))}, Dimensions: geo.XY},
+			"MultiCurve must not contain Polygon", nil, "MultiCurve[0]"},
+	}
+	for _, test := range tests {
+		err := test.geometry.Validate()
+		if test.reason == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected validity error %v", test.name, err)
+			}
+			continue
+		}
+
+		var validityErr *geo.ValidityError
+		if !errors.As(err, &validityErr) {
+			t.Errorf("%v: expected validity error, got %v", test.name, err)
+			continue
+		}
+		if validityErr.Reason != test.reason || validityErr.Path != test.path {
+			t.Errorf("%v: expected %v in %v, got %v", test.name, test.reason, test.path, err)
+		}
+		if len(validityErr.Location) != len(test.location) ||
+			len(test.location) == 2 && (validityErr.Location[0] != test.location[0] || validityErr.Location[1] != test.location[1]) {
+			t.Errorf("%v: expected location %v, got %v", test.name, test.location, validityErr.Location)
+		}
+	}
+}
+
+func TestValidReason(t *testing.T) {
+	g := geo.NewGISGeometry(&geo.Polygon{
+		LinearRings: []geo.LinearRing{{Coords: []float64{0, 0, 2, 2, 2, 0, 0, 2, 0, 0}, Dimensions: geo.XY}},
+		Dimensions:  geo.XY,
+	})
+	if g.IsValid() {
+		t.Error("bow-tie polygon was valid")
+	}
+	if reason := g.ValidReason(); reason != "Ring Self-intersection[1 1] in Polygon.ring[0]" {
+		t.Errorf("unexpected reason %v", reason)
+	}
+
+	shell, err := geo.LinearRingFromCoords([]float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hole, err := geo.LinearRingFromCoords([]float64{2, 2, 2, 4, 4, 4, 4, 2, 2, 2}, geo.XY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	polygon, err := geo.NewPolygon([]geo.LinearRing{*shell, *hole})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g = geo.NewGISGeometry(polygon)
+	if reason := g.ValidReason(); reason != "Valid Geometry" {
+		t.Errorf("unexpected reason %v", reason)
+	}
+}
+
+func TestNewPolygonEmpty(t *testing.T) {
+	if _, err := geo.NewPolygon(nil); err == nil {
+		t.Error("expected error creating polygon of no rings")
+	}
+	if _, err := geo.NewPoint([]float64{1, 2, 3}, geo.XY); err == nil {
+		t.Error("expected error creating XY point of 3 ordinates")
+	}
+}
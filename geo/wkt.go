@@ -0,0 +1,195 @@
+package geo
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+	https://postgis.net/docs/ST_AsEWKT.html
+
+Geometry is written as PostGIS writes extended WKT: the SRID is given as a
+"SRID=n;" prefix, Z and ZM geometries are identified by their number of
+ordinates, and M geometries by an "M" suffix on every type written, including
+nested ones such as GEOMETRYCOLLECTIONM(POINTM(1 2 3)). Members of multi
+geometries are written without their type where PostGIS omits it, for example
+the LineStrings of a CompoundCurve.
+*/
+
+// Get the extended WKT representation of the GISGeometry, as returned by ST_AsEWKT
+func (g GISGeometry) EWKT() string {
+	var sb strings.Builder
+	if g.SRIDFlag || g.SRID != 0 {
+		sb.WriteString("SRID=" + strconv.FormatUint(uint64(g.SRID), 10) + ";")
+	}
+	sb.WriteString(g.WKT())
+	return sb.String()
+}
+
+// Get the WKT representation of the GISGeometry without its SRID
+func (g GISGeometry) WKT() string {
+	var sb strings.Builder
+	if g.Geometry != nil {
+		writeWKT(&sb, g.Geometry)
+	}
+	return sb.String()
+}
+
+// Write the tagged WKT of a geometry, with the M suffix if it has only M
+func writeWKT(sb *strings.Builder, g GeometrySubtype) {
+	sb.WriteString(wktTag(g.GetGISGeometryType()))
+	if g.GetDimensions() == XYM {
+		sb.WriteString("M")
+	}
+	writeWKTBody(sb, g)
+}
+
+// Get the WKT type name of a geometry type
+func wktTag(t GISGeometryType) string {
+	switch t {
+	case PointType:
+		return "POINT"
+	case LineStringType:
+		return "LINESTRING"
+	case PolygonType:
+		return "POLYGON"
+	case MultiPointType:
+		return "MULTIPOINT"
+	case MultiLineStringType:
+		return "MULTILINESTRING"
+	case MultiPolygonType:
+		return "MULTIPOLYGON"
+	case GeometryCollectionType:
+		return "GEOMETRYCOLLECTION"
+	case CircularStringType:
+		return "CIRCULARSTRING"
+	case CompoundCurveType:
+		return "COMPOUNDCURVE"
+	case CurvePolygonType:
+		return "CURVEPOLYGON"
+	case MultiCurveType:
+		return "MULTICURVE"
+	case MultiSurfaceType:
+		return "MULTISURFACE"
+	case PolyHedralSurfaceType:
+		return "POLYHEDRALSURFACE"
+	case TINType:
+		return "TIN"
+	case TriangleType:
+		return "TRIANGLE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Write the WKT of a geometry following its type, such as "(1 2)" or " EMPTY"
+func writeWKTBody(sb *strings.Builder, g GeometrySubtype) {
+	switch t := g.(type) {
+	case *Point:
+		if pointEmpty(t.Coords) {
+			sb.WriteString(" EMPTY")
+			return
+		}
+		sb.WriteString("(")
+		writeWKTCoords(sb, t.Coords, t.Dimensions)
+		sb.WriteString(")")
+	case *LineString:
+		writeWKTSequence(sb, t.Coords, t.Dimensions)
+	case *CircularString:
+		writeWKTSequence(sb, t.Coords, t.Dimensions)
+	case *LinearRing:
+		writeWKTSequence(sb, t.Coords, t.Dimensions)
+	case *MultiPoint:
+		writeWKTSequence(sb, t.Coords, t.Dimensions)
+	case *Triangle:
+		if t.NumPoints() == 0 {
+			sb.WriteString(" EMPTY")
+			return
+		}
+		sb.WriteString("(")
+		writeWKTSequence(sb, t.Coords, t.Dimensions)
+		sb.WriteString(")")
+	case *Polygon:
+		writeWKTParts(sb, len(t.LinearRings), func(i int) { writeWKTBody(sb, &t.LinearRings[i]) })
+	case *MultiLineString:
+		writeWKTParts(sb, len(t.LineStrings), func(i int) { writeWKTBody(sb, &t.LineStrings[i]) })
+	case *MultiPolygon:
+		writeWKTParts(sb, len(t.Polygons), func(i int) { writeWKTBody(sb, &t.Polygons[i]) })
+	case *PolyHedralSurface:
+		writeWKTParts(sb, len(t.Polygons), func(i int) { writeWKTBody(sb, &t.Polygons[i]) })
+	case *TIN:
+		writeWKTParts(sb, len(t.Triangles), func(i int) { writeWKTBody(sb, &t.Triangles[i]) })
+	case *GeometryCollection:
+		writeWKTParts(sb, len(t.Geometry), func(i int) { writeWKT(sb, t.Geometry[i]) })
+	case *CompoundCurve:
+		writeWKTParts(sb, len(t.Geometry), func(i int) { writeWKTMember(sb, t.Geometry[i], LineStringType) })
+	case *CurvePolygon:
+		writeWKTParts(sb, len(t.Geometry), func(i int) { writeWKTMember(sb, t.Geometry[i], LineStringType) })
+	case *MultiCurve:
+		writeWKTParts(sb, len(t.Geometry), func(i int) { writeWKTMember(sb, t.Geometry[i], LineStringType) })
+	case *MultiSurface:
+		writeWKTParts(sb, len(t.Geometry), func(i int) { writeWKTMember(sb, t.Geometry[i], PolygonType) })
+	}
+}
+
+// Write a member of a multi geometry, omitting the type of members of the default type
+func writeWKTMember(sb *strings.Builder, g GeometrySubtype, defaultType GISGeometryType) {
+	if g.GetGISGeometryType() == defaultType {
+		writeWKTBody(sb, g)
+		return
+	}
+	writeWKT(sb, g)
+}
+
+// Write n comma separated parts in parentheses, or " EMPTY" if there are none
+func writeWKTParts(sb *strings.Builder, n int, part func(i int)) {
+	if n == 0 {
+		sb.WriteString(" EMPTY")
+		return
+	}
+	sb.WriteString("(")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		part(i)
+	}
+	sb.WriteString(")")
+}
+
+// Write a point sequence in parentheses, or " EMPTY" if it has no points
+func writeWKTSequence(sb *strings.Builder, coords []float64, dimensions Dimensions) {
+	if coordCount(coords, dimensions) == 0 {
+		sb.WriteString(" EMPTY")
+		return
+	}
+	sb.WriteString("(")
+	writeWKTCoords(sb, coords, dimensions)
+	sb.WriteString(")")
+}
+
+// Write the ordinates of each point separated by spaces, with points separated by commas
+func writeWKTCoords(sb *strings.Builder, coords []float64, dimensions Dimensions) {
+	for i := 0; i < coordCount(coords, dimensions); i++ {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		for j, c := range coordAt(coords, dimensions, i) {
+			if j > 0 {
+				sb.WriteString(" ")
+			}
+			sb.WriteString(strconv.FormatFloat(c, 'f', -1, 64))
+		}
+	}
+}
+
+// Report whether the ordinates are those of an empty point, which PostGIS encodes as NaN
+func pointEmpty(coords []float64) bool {
+	for _, c := range coords {
+		if !math.IsNaN(c) {
+			return false
+		}
+	}
+	return true
+}